@@ -0,0 +1,217 @@
+package tool
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+// ZipDir Packs the content of src directory into dest zip file, skipping paths matching any of excludes.
+// Patterns use filepath.Match syntax and are matched against both the slash-separated relative path and the base name.
+func ZipDir(src, dest string, excludes ...string) (err error) {
+	out, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+	defer closeWith(out, &err)
+
+	zw := zip.NewWriter(out)
+	defer closeWith(zw, &err)
+
+	return walkArchive(src, dest, excludes, func(name string, d fs.DirEntry, fullPath string) error {
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		header, err := zip.FileInfoHeader(info)
+		if err != nil {
+			return err
+		}
+		header.Name = name
+		if d.IsDir() {
+			header.Name += "/"
+		} else {
+			header.Method = zip.Deflate
+		}
+		w, err := zw.CreateHeader(header)
+		if err != nil || d.IsDir() {
+			return err
+		}
+		return copyFileTo(w, fullPath)
+	})
+}
+
+// Unzip Extracts src zip file into dest directory, rejecting entries that would escape dest (zip-slip)
+func Unzip(src, dest string) error {
+	zr, err := zip.OpenReader(src)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = zr.Close() }()
+
+	for _, f := range zr.File {
+		if err = extractEntry(dest, f.Name, f.Mode(), func() (io.ReadCloser, error) { return f.Open() }); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TarGzDir Packs the content of src directory into dest .tar.gz file, excludes work as in ZipDir
+func TarGzDir(src, dest string, excludes ...string) (err error) {
+	out, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+	defer closeWith(out, &err)
+
+	gw := gzip.NewWriter(out)
+	defer closeWith(gw, &err)
+	tw := tar.NewWriter(gw)
+	defer closeWith(tw, &err)
+
+	return walkArchive(src, dest, excludes, func(name string, d fs.DirEntry, fullPath string) error {
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		header.Name = name
+		if d.IsDir() {
+			header.Name += "/"
+		}
+		if err = tw.WriteHeader(header); err != nil || d.IsDir() {
+			return err
+		}
+		return copyFileTo(tw, fullPath)
+	})
+}
+
+// UntarGz Extracts src .tar.gz file into dest directory, rejecting entries that would escape dest.
+// Only directories and regular files are supported, links and devices result in error.
+func UntarGz(src, dest string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = in.Close() }()
+
+	gr, err := gzip.NewReader(in)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = gr.Close() }()
+
+	tr := tar.NewReader(gr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		switch header.Typeflag {
+		case tar.TypeDir, tar.TypeReg:
+		default:
+			return fmt.Errorf("unsupported tar entry %s of type %c", header.Name, header.Typeflag)
+		}
+		err = extractEntry(dest, header.Name, header.FileInfo().Mode(), func() (io.ReadCloser, error) {
+			return io.NopCloser(tr), nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// walkArchive Walks src calling add with slash-separated relative names of regular files and directories
+func walkArchive(src, dest string, excludes []string, add func(name string, d fs.DirEntry, fullPath string) error) error {
+	absDest, _ := filepath.Abs(dest)
+	return filepath.WalkDir(src, func(fullPath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, fullPath)
+		if err != nil || rel == "." {
+			return err
+		}
+		if absPath, _ := filepath.Abs(fullPath); absPath == absDest {
+			return nil // archive is being written inside src
+		}
+		name := filepath.ToSlash(rel)
+		for _, pattern := range excludes {
+			matchedName, _ := path.Match(pattern, name)
+			matchedBase, _ := path.Match(pattern, d.Name())
+			if matchedName || matchedBase {
+				if d.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+		}
+		if !d.IsDir() && !d.Type().IsRegular() {
+			return nil
+		}
+		return add(name, d, fullPath)
+	})
+}
+
+// extractEntry Creates a directory or a file from an archive entry inside dest
+func extractEntry(dest, name string, mode fs.FileMode, open func() (io.ReadCloser, error)) error {
+	target, err := JoinSecure(dest, filepath.FromSlash(name))
+	if err != nil {
+		return fmt.Errorf("archive entry %s: %w", name, err)
+	}
+
+	if mode.IsDir() {
+		return os.MkdirAll(target, 0o755)
+	}
+	if !mode.IsRegular() {
+		return fmt.Errorf("unsupported archive entry %s with mode %s", name, mode)
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+	r, err := open()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = r.Close() }()
+
+	f, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, mode.Perm()|0o200)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(f, r); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func copyFileTo(w io.Writer, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+	_, err = io.Copy(w, f)
+	return err
+}
+
+// closeWith Closes c and stores its error into err unless there is one already, meant for defer
+func closeWith(c io.Closer, err *error) {
+	if closeErr := c.Close(); *err == nil {
+		*err = closeErr
+	}
+}
@@ -0,0 +1,84 @@
+package tool
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+)
+
+func writeTestTree(s *ToolTestSuite, dir string) {
+	for name, content := range map[string]string{
+		"a.txt":         "a",
+		"sub/b.txt":     "b",
+		"sub/debug.log": "log",
+		"cache/c.txt":   "c",
+	} {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		s.Require().NoError(os.MkdirAll(filepath.Dir(path), 0o755))
+		s.Require().NoError(os.WriteFile(path, []byte(content), 0o644))
+	}
+}
+
+func assertExtractedTree(s *ToolTestSuite, dir string) {
+	s.Equal("a", string(MustReturn(os.ReadFile(filepath.Join(dir, "a.txt")))))
+	s.Equal("b", string(MustReturn(os.ReadFile(filepath.Join(dir, "sub", "b.txt")))))
+	s.NoFileExists(filepath.Join(dir, "sub", "debug.log"))
+	s.NoDirExists(filepath.Join(dir, "cache"))
+}
+
+func (s *ToolTestSuite) TestZip() {
+	tmp := s.T().TempDir()
+	src := filepath.Join(tmp, "src")
+	writeTestTree(s, src)
+
+	s.Run("roundtrip", func() {
+		archive := filepath.Join(src, "out.zip") // inside src on purpose
+		s.Require().NoError(ZipDir(src, archive, "*.log", "cache"))
+		dest := filepath.Join(tmp, "unzipped")
+		s.Require().NoError(Unzip(archive, dest))
+		assertExtractedTree(s, dest)
+		s.NoFileExists(filepath.Join(dest, "out.zip"))
+	})
+
+	s.Run("zip slip", func() {
+		archive := filepath.Join(tmp, "evil.zip")
+		f := MustReturn(os.Create(archive))
+		zw := zip.NewWriter(f)
+		w := MustReturn(zw.Create("../evil.txt"))
+		_, _ = w.Write([]byte("evil"))
+		s.Require().NoError(zw.Close())
+		s.Require().NoError(f.Close())
+
+		s.ErrorIs(Unzip(archive, filepath.Join(tmp, "evil")), ErrPathTraversal)
+		s.NoFileExists(filepath.Join(tmp, "evil.txt"))
+	})
+}
+
+func (s *ToolTestSuite) TestTarGz() {
+	tmp := s.T().TempDir()
+	src := filepath.Join(tmp, "src")
+	writeTestTree(s, src)
+
+	s.Run("roundtrip", func() {
+		archive := filepath.Join(tmp, "out.tar.gz")
+		s.Require().NoError(TarGzDir(src, archive, "*.log", "cache"))
+		dest := filepath.Join(tmp, "untarred")
+		s.Require().NoError(UntarGz(archive, dest))
+		assertExtractedTree(s, dest)
+	})
+
+	s.Run("links rejected", func() {
+		archive := filepath.Join(tmp, "link.tar.gz")
+		f := MustReturn(os.Create(archive))
+		gw := gzip.NewWriter(f)
+		tw := tar.NewWriter(gw)
+		s.Require().NoError(tw.WriteHeader(&tar.Header{Name: "link", Typeflag: tar.TypeSymlink, Linkname: "/etc/passwd"}))
+		s.Require().NoError(tw.Close())
+		s.Require().NoError(gw.Close())
+		s.Require().NoError(f.Close())
+
+		s.ErrorContains(UntarGz(archive, filepath.Join(tmp, "link")), "unsupported tar entry")
+	})
+}
@@ -0,0 +1,77 @@
+package tool
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"sync"
+)
+
+// BuildInfoData Describes the running binary
+type BuildInfoData struct {
+	Path      string `json:"path,omitempty"`
+	Version   string `json:"version,omitempty"`
+	Revision  string `json:"revision,omitempty"`
+	Time      string `json:"time,omitempty"`
+	Dirty     bool   `json:"dirty,omitempty"`
+	GoVersion string `json:"goVersion"`
+}
+
+var (
+	buildInfoOnce sync.Once
+	buildInfo     BuildInfoData
+
+	machineIDFiles = []string{"/etc/machine-id", "/var/lib/dbus/machine-id", "/etc/hostid"}
+)
+
+// BuildInfo Returns main module version and VCS stamp of the running binary, the value is read once
+func BuildInfo() BuildInfoData {
+	buildInfoOnce.Do(func() {
+		buildInfo.GoVersion = runtime.Version()
+		info, ok := debug.ReadBuildInfo()
+		if !ok {
+			return
+		}
+		buildInfo.Path = info.Main.Path
+		buildInfo.Version = info.Main.Version
+		for _, setting := range info.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				buildInfo.Revision = setting.Value
+			case "vcs.time":
+				buildInfo.Time = setting.Value
+			case "vcs.modified":
+				buildInfo.Dirty = setting.Value == "true"
+			}
+		}
+	})
+	return buildInfo
+}
+
+// Hostname Returns the host name reported by the kernel, empty on error
+func Hostname() string {
+	name, err := os.Hostname()
+	if Try(err, true) {
+		return ""
+	}
+	return name
+}
+
+// MachineID Returns a stable identifier of the host, reading OS machine-id files
+// and falling back to a hash of the host name where they are not available
+func MachineID() string {
+	for _, path := range machineIDFiles {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		if id := strings.TrimSpace(string(b)); id != "" {
+			return id
+		}
+	}
+	sum := sha256.Sum256([]byte(Hostname()))
+	return hex.EncodeToString(sum[:16])
+}
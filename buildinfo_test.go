@@ -0,0 +1,37 @@
+package tool
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"runtime"
+)
+
+func (s *ToolTestSuite) TestBuildInfo() {
+	info := BuildInfo()
+	s.Equal(runtime.Version(), info.GoVersion)
+	s.Equal(info, BuildInfo())
+	s.Contains(Jsonify(info).String(), `"goVersion":"`+runtime.Version()+`"`)
+}
+
+func (s *ToolTestSuite) TestHostname() {
+	s.NotEmpty(Hostname())
+}
+
+func (s *ToolTestSuite) TestMachineID() {
+	s.Run("stable", func() {
+		s.NotEmpty(MachineID())
+		s.Equal(MachineID(), MachineID())
+	})
+	s.Run("fallback", func() {
+		files := machineIDFiles
+		defer func() { machineIDFiles = files }()
+		machineIDFiles = []string{filepath.Join(s.T().TempDir(), "missing")}
+		s.Len(MachineID(), 32)
+	})
+}
+
+func (s *ToolTestSuite) TestNoDefaultServeMuxPprof() {
+	_, pattern := http.DefaultServeMux.Handler(httptest.NewRequest(http.MethodGet, "/debug/pprof/", nil))
+	s.Empty(pattern, "tool must not register pprof handlers on http.DefaultServeMux")
+}
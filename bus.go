@@ -0,0 +1,177 @@
+package tool
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrNoStore Returned by Bus methods that need persistence when the bus has no Store
+var ErrNoStore = errors.New("bus has no store")
+
+type (
+	// Event Published bus message, Seq is assigned by the bus and grows monotonically starting from 1
+	Event[T any] struct {
+		Seq     uint64
+		Time    time.Time
+		Payload T
+	}
+
+	// Store Persistence hook of the Bus
+	Store[T any] interface {
+		// Append Persists the event, called before the event is delivered
+		Append(event Event[T]) error
+		// Events Returns persisted events with Seq >= from in publish order
+		Events(from uint64) ([]Event[T], error)
+		// LastSeq Returns Seq of the last persisted event, 0 if there are none
+		LastSeq() (uint64, error)
+	}
+
+	// Bus In-process publish/subscribe bus with optional persistence and replay.
+	// Handlers are called synchronously in publish order, handler panics are recovered and logged.
+	// Handlers must not publish to the same bus synchronously.
+	Bus[T any] struct {
+		mu    sync.Mutex
+		seq   uint64
+		store Store[T]
+
+		subMu  sync.RWMutex
+		subID  uint64
+		subs   map[uint64]func(Event[T])
+		subIDs []uint64
+	}
+
+	// MemoryStore Store implementation that keeps events in memory, safe for concurrent use
+	MemoryStore[T any] struct {
+		mu     sync.RWMutex
+		events []Event[T]
+	}
+)
+
+// NewBus Returns a new bus, store is optional and may be nil.
+// When store is given, sequence numbering continues from its last event.
+func NewBus[T any](store Store[T]) (*Bus[T], error) {
+	b := &Bus[T]{store: store, subs: map[uint64]func(Event[T]){}}
+	if store != nil {
+		seq, err := store.LastSeq()
+		if err != nil {
+			return nil, err
+		}
+		b.seq = seq
+	}
+	return b, nil
+}
+
+// Publish Persists the payload, if bus has a Store, and delivers it to the subscribers
+func (b *Bus[T]) Publish(payload T) (Event[T], error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	event := Event[T]{Seq: b.seq + 1, Time: clockNow(), Payload: payload}
+	if b.store != nil {
+		if err := b.store.Append(event); err != nil {
+			return event, err
+		}
+	}
+	b.seq = event.Seq
+	b.deliver(event)
+	return event, nil
+}
+
+// Subscribe Registers a handler for events published from now on, returns a function to unsubscribe
+func (b *Bus[T]) Subscribe(fn func(Event[T])) (unsubscribe func()) {
+	b.subMu.Lock()
+	defer b.subMu.Unlock()
+
+	b.subID++
+	id := b.subID
+	b.subs[id] = fn
+	b.subIDs = append(b.subIDs, id)
+
+	return func() {
+		b.subMu.Lock()
+		defer b.subMu.Unlock()
+		delete(b.subs, id)
+		for i := range b.subIDs {
+			if b.subIDs[i] == id {
+				b.subIDs = append(b.subIDs[:i], b.subIDs[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// SubscribeFrom Replays persisted events starting with Seq from, then subscribes for new ones,
+// no events are missed or delivered twice in between
+func (b *Bus[T]) SubscribeFrom(from uint64, fn func(Event[T])) (unsubscribe func(), err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err = b.Replay(from, fn); err != nil {
+		return nil, err
+	}
+	return b.Subscribe(fn), nil
+}
+
+// Replay Passes persisted events starting with Seq from to fn, in publish order
+func (b *Bus[T]) Replay(from uint64, fn func(Event[T])) error {
+	if b.store == nil {
+		return ErrNoStore
+	}
+	events, err := b.store.Events(from)
+	if err != nil {
+		return err
+	}
+	for _, event := range events {
+		b.call(fn, event)
+	}
+	return nil
+}
+
+func (b *Bus[T]) deliver(event Event[T]) {
+	b.subMu.RLock()
+	handlers := make([]func(Event[T]), 0, len(b.subIDs))
+	for _, id := range b.subIDs {
+		handlers = append(handlers, b.subs[id])
+	}
+	b.subMu.RUnlock()
+
+	for _, fn := range handlers {
+		b.call(fn, event)
+	}
+}
+
+func (b *Bus[T]) call(fn func(Event[T]), event Event[T]) {
+	_ = Recoverer(0, func() { fn(event) }, "bus handler")
+}
+
+// Append Implements Store
+func (s *MemoryStore[T]) Append(event Event[T]) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, event)
+	return nil
+}
+
+// Events Implements Store
+func (s *MemoryStore[T]) Events(from uint64) ([]Event[T], error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var res []Event[T]
+	for _, event := range s.events {
+		if event.Seq >= from {
+			res = append(res, event)
+		}
+	}
+	return res, nil
+}
+
+// LastSeq Implements Store
+func (s *MemoryStore[T]) LastSeq() (uint64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if len(s.events) == 0 {
+		return 0, nil
+	}
+	return s.events[len(s.events)-1].Seq, nil
+}
@@ -0,0 +1,67 @@
+package tool
+
+import (
+	"errors"
+)
+
+type failingStore[T any] struct {
+	MemoryStore[T]
+}
+
+func (s *failingStore[T]) Append(Event[T]) error {
+	return errors.New("append failed")
+}
+
+func (s *ToolTestSuite) TestBus() {
+	s.Run("no store", func() {
+		bus, err := NewBus[string](nil)
+		s.Require().NoError(err)
+
+		var got []string
+		unsubscribe := bus.Subscribe(func(e Event[string]) { got = append(got, e.Payload) })
+		bus.Subscribe(func(Event[string]) { panic("handler panic") })
+
+		e, err := bus.Publish("a")
+		s.NoError(err)
+		s.Equal(uint64(1), e.Seq)
+		unsubscribe()
+		_, err = bus.Publish("b")
+		s.NoError(err)
+
+		s.Equal([]string{"a"}, got)
+		s.ErrorIs(bus.Replay(0, func(Event[string]) {}), ErrNoStore)
+	})
+
+	s.Run("replay", func() {
+		store := &MemoryStore[int]{}
+		bus, err := NewBus[int](store)
+		s.Require().NoError(err)
+		for i := 1; i <= 3; i++ {
+			_, err = bus.Publish(i)
+			s.NoError(err)
+		}
+
+		var got []int
+		s.NoError(bus.Replay(2, func(e Event[int]) { got = append(got, e.Payload) }))
+		s.Equal([]int{2, 3}, got)
+
+		restarted, err := NewBus[int](store)
+		s.Require().NoError(err)
+		got = nil
+		_, err = restarted.SubscribeFrom(3, func(e Event[int]) { got = append(got, int(e.Seq)) })
+		s.NoError(err)
+		_, err = restarted.Publish(4)
+		s.NoError(err)
+		s.Equal([]int{3, 4}, got)
+	})
+
+	s.Run("store error", func() {
+		bus, err := NewBus[int](&failingStore[int]{})
+		s.Require().NoError(err)
+		delivered := false
+		bus.Subscribe(func(Event[int]) { delivered = true })
+		_, err = bus.Publish(1)
+		s.Error(err)
+		s.False(delivered)
+	})
+}
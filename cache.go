@@ -0,0 +1,239 @@
+package tool
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+type (
+	// CacheConfig Cache settings, adjusted with options
+	CacheConfig struct {
+		// MaxSize Maximum number of entries, least recently used ones are evicted above it, <1 for unlimited
+		MaxSize int
+		// TTL Lifetime of entries stored without explicit TTL, <=0 for no expiration
+		TTL time.Duration
+		// Hooks Metrics callbacks
+		Hooks CacheHooks
+	}
+
+	// CacheHooks Metrics callbacks of Cache, nil ones are skipped. They are called with the cache locked
+	// and must not use it.
+	CacheHooks struct {
+		// OnHit Called when a lookup finds a live entry
+		OnHit func()
+		// OnMiss Called when a lookup finds no entry or an expired one
+		OnMiss func()
+		// OnEvict Called when an entry is dropped because of expiration or size limit, not on Delete or Clear
+		OnEvict func(expired bool)
+	}
+
+	// Cache In-memory key-value cache with per-entry TTL and LRU eviction, safe for concurrent use.
+	// Expired entries are dropped lazily on access, on eviction and by DeleteExpired.
+	Cache[K comparable, V any] struct {
+		cfg   CacheConfig
+		mu    sync.Mutex
+		lru   *list.List
+		items map[K]*list.Element
+		calls map[K]*cacheCall[V]
+	}
+
+	cacheEntry[K comparable, V any] struct {
+		key     K
+		value   V
+		expires time.Time
+	}
+
+	cacheCall[V any] struct {
+		done  chan struct{}
+		value V
+		err   error
+	}
+)
+
+// DefaultCacheConfig Used as the base of NewCache options
+var DefaultCacheConfig = CacheConfig{MaxSize: 1000}
+
+// WithCacheMaxSize Sets maximum number of entries, <1 for unlimited
+func WithCacheMaxSize(size int) Option[CacheConfig] {
+	return func(c *CacheConfig) { c.MaxSize = size }
+}
+
+// WithCacheTTL Sets default entry lifetime, <=0 for no expiration
+func WithCacheTTL(ttl time.Duration) Option[CacheConfig] {
+	return func(c *CacheConfig) { c.TTL = ttl }
+}
+
+// WithCacheHooks Sets metrics callbacks
+func WithCacheHooks(hooks CacheHooks) Option[CacheConfig] {
+	return func(c *CacheConfig) { c.Hooks = hooks }
+}
+
+// NewCache Returns empty cache configured with options applied over DefaultCacheConfig
+func NewCache[K comparable, V any](opts ...Option[CacheConfig]) *Cache[K, V] {
+	cfg, _ := Build(DefaultCacheConfig, opts...)
+	return &Cache[K, V]{cfg: cfg, lru: list.New(), items: map[K]*list.Element{}, calls: map[K]*cacheCall[V]{}}
+}
+
+// Get Returns live value of key and true, or zero value and false
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.get(key)
+}
+
+// Set Stores value under key with the default TTL
+func (c *Cache[K, V]) Set(key K, value V) {
+	c.SetTTL(key, value, c.cfg.TTL)
+}
+
+// SetTTL Stores value under key expiring after ttl, <=0 for no expiration
+func (c *Cache[K, V]) SetTTL(key K, value V, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.set(key, value, ttl)
+}
+
+// GetOrCompute Returns live value of key, or computes it with fn and stores it with the default TTL.
+// Concurrent calls for the same missing key wait for a single fn call and share its result.
+// Errors are not cached, panics of fn (including Must ones) are recovered and returned as errors.
+func (c *Cache[K, V]) GetOrCompute(key K, fn func() (V, error)) (V, error) {
+	c.mu.Lock()
+	if value, ok := c.get(key); ok {
+		c.mu.Unlock()
+		return value, nil
+	}
+	if call, ok := c.calls[key]; ok {
+		c.mu.Unlock()
+		<-call.done
+		return call.value, call.err
+	}
+	call := &cacheCall[V]{done: make(chan struct{})}
+	c.calls[key] = call
+	c.mu.Unlock()
+
+	call.value, call.err = compute(fn)
+
+	c.mu.Lock()
+	delete(c.calls, key)
+	if call.err == nil {
+		c.set(key, call.value, c.cfg.TTL)
+	}
+	c.mu.Unlock()
+	close(call.done)
+	return call.value, call.err
+}
+
+// Delete Removes key, returns true if it was present
+func (c *Cache[K, V]) Delete(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.items[key]
+	if ok {
+		c.remove(elem)
+	}
+	return ok
+}
+
+// DeleteExpired Removes all expired entries, returns their number
+func (c *Cache[K, V]) DeleteExpired() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := clockNow()
+	removed := 0
+	for elem := c.lru.Front(); elem != nil; {
+		next := elem.Next()
+		if c.expired(elem, now) {
+			c.evict(elem, true)
+			removed++
+		}
+		elem = next
+	}
+	return removed
+}
+
+// Len Returns number of stored entries, including expired ones not dropped yet
+func (c *Cache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
+
+// Clear Removes all entries
+func (c *Cache[K, V]) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lru.Init()
+	c.items = map[K]*list.Element{}
+}
+
+func (c *Cache[K, V]) get(key K) (value V, ok bool) {
+	elem, ok := c.items[key]
+	if ok && c.expired(elem, clockNow()) {
+		c.evict(elem, true)
+		ok = false
+	}
+	if !ok {
+		callHook(c.cfg.Hooks.OnMiss)
+		return value, false
+	}
+	callHook(c.cfg.Hooks.OnHit)
+	c.lru.MoveToFront(elem)
+	return elem.Value.(*cacheEntry[K, V]).value, true
+}
+
+func (c *Cache[K, V]) set(key K, value V, ttl time.Duration) {
+	entry := &cacheEntry[K, V]{key: key, value: value}
+	if ttl > 0 {
+		entry.expires = clockNow().Add(ttl)
+	}
+	if elem, ok := c.items[key]; ok {
+		elem.Value = entry
+		c.lru.MoveToFront(elem)
+		return
+	}
+	c.items[key] = c.lru.PushFront(entry)
+
+	for c.cfg.MaxSize > 0 && c.lru.Len() > c.cfg.MaxSize {
+		oldest := c.lru.Back()
+		c.evict(oldest, c.expired(oldest, clockNow()))
+	}
+}
+
+func (c *Cache[K, V]) expired(elem *list.Element, now time.Time) bool {
+	expires := elem.Value.(*cacheEntry[K, V]).expires
+	return !expires.IsZero() && !now.Before(expires)
+}
+
+func (c *Cache[K, V]) evict(elem *list.Element, expired bool) {
+	c.remove(elem)
+	if c.cfg.Hooks.OnEvict != nil {
+		c.cfg.Hooks.OnEvict(expired)
+	}
+}
+
+func (c *Cache[K, V]) remove(elem *list.Element) {
+	c.lru.Remove(elem)
+	delete(c.items, elem.Value.(*cacheEntry[K, V]).key)
+}
+
+// compute Calls fn, converting its panic into error
+func compute[V any](fn func() (V, error)) (value V, err error) {
+	defer CatchAll(func(caught error) { err = caught })
+	return fn()
+}
+
+func callHook(hook func()) {
+	if hook != nil {
+		hook()
+	}
+}
+
+// Memoize Returns fn caching its successful results per key, forever or for the optional ttl.
+// Concurrent calls for the same key share a single fn call, errors are not cached.
+func Memoize[K comparable, V any](fn func(K) (V, error), ttl ...time.Duration) func(K) (V, error) {
+	cache := NewCache[K, V](WithCacheMaxSize(0), WithCacheTTL(NonZero(ttl...)))
+	return func(key K) (V, error) {
+		return cache.GetOrCompute(key, func() (V, error) { return fn(key) })
+	}
+}
@@ -0,0 +1,149 @@
+package tool
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+func (s *ToolTestSuite) TestCache() {
+	clock := NewManualClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	SetClock(clock)
+	defer SetClock(nil)
+
+	s.Run("ttl", func() {
+		var hits, misses, expired atomic.Int32
+		c := NewCache[string, int](WithCacheTTL(time.Minute), WithCacheHooks(CacheHooks{
+			OnHit:   func() { hits.Add(1) },
+			OnMiss:  func() { misses.Add(1) },
+			OnEvict: func(exp bool) { s.True(exp); expired.Add(1) },
+		}))
+		c.Set("a", 1)
+		c.SetTTL("b", 2, time.Hour)
+		c.SetTTL("c", 3, 0)
+
+		v, ok := c.Get("a")
+		s.True(ok)
+		s.Equal(1, v)
+
+		clock.Advance(time.Minute)
+		_, ok = c.Get("a")
+		s.False(ok)
+		_, ok = c.Get("b")
+		s.True(ok)
+
+		clock.Advance(time.Hour)
+		s.Equal(2, c.Len())
+		s.Equal(1, c.DeleteExpired())
+		v, ok = c.Get("c")
+		s.True(ok)
+		s.Equal(3, v)
+
+		s.Equal(int32(3), hits.Load())
+		s.Equal(int32(1), misses.Load())
+		s.Equal(int32(2), expired.Load())
+	})
+
+	s.Run("lru", func() {
+		var evicted []bool
+		c := NewCache[int, string](WithCacheMaxSize(2), WithCacheHooks(CacheHooks{
+			OnEvict: func(exp bool) { evicted = append(evicted, exp) },
+		}))
+		c.Set(1, "one")
+		c.Set(2, "two")
+		c.Get(1)
+		c.Set(3, "three")
+
+		_, ok := c.Get(2)
+		s.False(ok)
+		_, ok = c.Get(1)
+		s.True(ok)
+		s.Equal(2, c.Len())
+		s.Equal([]bool{false}, evicted)
+
+		s.True(c.Delete(1))
+		s.False(c.Delete(1))
+		c.Clear()
+		s.Zero(c.Len())
+		s.Len(evicted, 1)
+	})
+
+	s.Run("get or compute", func() {
+		c := NewCache[string, int]()
+		var calls atomic.Int32
+		release := make(chan struct{})
+		fn := func() (int, error) {
+			calls.Add(1)
+			<-release
+			return 42, nil
+		}
+
+		var wg sync.WaitGroup
+		results := make([]int, 10)
+		for i := range results {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				results[i] = MustReturn(c.GetOrCompute("k", fn))
+			}(i)
+		}
+		time.Sleep(20 * time.Millisecond)
+		close(release)
+		wg.Wait()
+
+		s.Equal(int32(1), calls.Load())
+		for _, v := range results {
+			s.Equal(42, v)
+		}
+
+		errBoom := errors.New("boom")
+		_, err := c.GetOrCompute("e", func() (int, error) { return 0, errBoom })
+		s.ErrorIs(err, errBoom)
+		_, ok := c.Get("e")
+		s.False(ok)
+
+		_, err = c.GetOrCompute("p", func() (int, error) { return MustReturn(0, errBoom), nil })
+		s.ErrorIs(err, errBoom)
+
+		v, err := c.GetOrCompute("k", func() (int, error) { return 0, errBoom })
+		s.NoError(err)
+		s.Equal(42, v)
+	})
+}
+
+func (s *ToolTestSuite) TestMemoize() {
+	clock := NewManualClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	SetClock(clock)
+	defer SetClock(nil)
+
+	calls := map[int]int{}
+	square := Memoize(func(n int) (int, error) {
+		calls[n]++
+		if n < 0 {
+			return 0, errors.New("negative")
+		}
+		return n * n, nil
+	}, time.Minute)
+
+	s.Equal(4, MustReturn(square(2)))
+	s.Equal(4, MustReturn(square(2)))
+	s.Equal(9, MustReturn(square(3)))
+	s.Equal(map[int]int{2: 1, 3: 1}, calls)
+
+	clock.Advance(time.Minute)
+	s.Equal(4, MustReturn(square(2)))
+	s.Equal(2, calls[2])
+
+	_, err := square(-1)
+	s.Error(err)
+	_, err = square(-1)
+	s.Error(err)
+	s.Equal(2, calls[-1])
+
+	forever := Memoize(func(n int) (int, error) { calls[n]++; return n, nil })
+	forever(10)
+	clock.Advance(24 * time.Hour)
+	forever(10)
+	s.Equal(1, calls[10])
+}
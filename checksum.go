@@ -0,0 +1,72 @@
+package tool
+
+import (
+	"crypto"
+	_ "crypto/md5" // register hashes usable with ReaderHash
+	_ "crypto/sha1"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// ErrChecksumMismatch Returned by VerifyChecksum when the file content differs from the expected checksum
+var ErrChecksumMismatch = errors.New("checksum mismatch")
+
+var checksumHashes = map[string]crypto.Hash{
+	"md5":    crypto.MD5,
+	"sha1":   crypto.SHA1,
+	"sha256": crypto.SHA256,
+	"sha512": crypto.SHA512,
+}
+
+// ReaderHash Returns hex digest of everything read from r using h
+func ReaderHash(r io.Reader, h crypto.Hash) (string, error) {
+	if !h.Available() {
+		return "", fmt.Errorf("hash %s is not available", h)
+	}
+	hash := h.New()
+	if _, err := io.Copy(hash, r); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// FileHash Returns hex digest of the file content using h
+func FileHash(path string, h crypto.Hash) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = f.Close() }()
+	return ReaderHash(f, h)
+}
+
+// FileSHA256 Returns hex SHA-256 digest of the file content
+func FileSHA256(path string) (string, error) {
+	return FileHash(path, crypto.SHA256)
+}
+
+// VerifyChecksum Checks the file against expected hex digest, either plain SHA-256
+// or prefixed with the algorithm, e.g. "sha512:<hex>", supports md5, sha1, sha256 and sha512
+func VerifyChecksum(path, expected string) error {
+	h := crypto.SHA256
+	if algo, digest, ok := strings.Cut(expected, ":"); ok {
+		if h, ok = checksumHashes[strings.ToLower(algo)]; !ok {
+			return fmt.Errorf("unsupported checksum algorithm %q", algo)
+		}
+		expected = digest
+	}
+	actual, err := FileHash(path, h)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(actual, strings.TrimSpace(expected)) {
+		return fmt.Errorf("%w: %s has %s %s", ErrChecksumMismatch, path, h, actual)
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package tool
+
+import (
+	"crypto"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func (s *ToolTestSuite) TestChecksum() {
+	path := filepath.Join(s.T().TempDir(), "artifact.bin")
+	s.Require().NoError(os.WriteFile(path, []byte("hello"), 0o600))
+	const helloSHA256 = "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+
+	s.Run("reader", func() {
+		sum, err := ReaderHash(strings.NewReader("hello"), crypto.MD5)
+		s.NoError(err)
+		s.Equal("5d41402abc4b2a76b9719d911017c592", sum)
+		_, err = ReaderHash(strings.NewReader("hello"), crypto.MD4)
+		s.Error(err)
+	})
+
+	s.Run("file", func() {
+		sum, err := FileSHA256(path)
+		s.NoError(err)
+		s.Equal(helloSHA256, sum)
+		_, err = FileSHA256(path + ".missing")
+		s.Error(err)
+	})
+
+	s.Run("verify", func() {
+		s.NoError(VerifyChecksum(path, helloSHA256))
+		s.NoError(VerifyChecksum(path, "SHA256:"+strings.ToUpper(helloSHA256)))
+		s.NoError(VerifyChecksum(path, "sha1:aaf4c61ddcc5e8a2dabede0f3b482cd9aea9434d"))
+		s.ErrorIs(VerifyChecksum(path, "sha1:"+helloSHA256), ErrChecksumMismatch)
+		s.ErrorContains(VerifyChecksum(path, "crc32:1234"), "unsupported")
+	})
+}
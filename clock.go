@@ -0,0 +1,94 @@
+package tool
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Clock Source of time for the package: timestamps, elapsed time and retry sleeps are read through it
+type Clock interface {
+	Now() time.Time
+	Sleep(d time.Duration)
+}
+
+// SystemClock Clock backed by the time package, used by default
+type SystemClock struct{}
+
+// Now Returns current time
+func (SystemClock) Now() time.Time { return time.Now() }
+
+// Sleep Pauses the current goroutine for d
+func (SystemClock) Sleep(d time.Duration) { time.Sleep(d) }
+
+// clock Current package clock, see SetClock
+var clock atomic.Pointer[clockHolder]
+
+type clockHolder struct{ Clock }
+
+// SetClock Replaces the package clock, pass nil to restore SystemClock.
+// Helpers driven by timers or sharing state with other processes, e.g. MustWithin, WatchPaths, SharedLimiter
+// and file locks, keep using real time.
+func SetClock(c Clock) {
+	if c == nil {
+		clock.Store(nil)
+		return
+	}
+	clock.Store(&clockHolder{c})
+}
+
+func clockNow() time.Time {
+	if c := clock.Load(); c != nil {
+		return c.Now()
+	}
+	return time.Now()
+}
+
+func clockSince(t time.Time) time.Duration {
+	return clockNow().Sub(t)
+}
+
+func clockSleep(d time.Duration) {
+	if c := clock.Load(); c != nil {
+		c.Sleep(d)
+		return
+	}
+	time.Sleep(d)
+}
+
+// ManualClock Clock that moves only when told to, Sleep advances it instantly. Safe for concurrent use.
+type ManualClock struct {
+	mu sync.Mutex
+	t  time.Time
+}
+
+// NewManualClock Returns a clock frozen at t
+func NewManualClock(t time.Time) *ManualClock {
+	return &ManualClock{t: t}
+}
+
+// Now Returns the clock time
+func (c *ManualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.t
+}
+
+// Sleep Advances the clock by d without blocking
+func (c *ManualClock) Sleep(d time.Duration) {
+	c.Advance(d)
+}
+
+// Advance Moves the clock forward by d
+func (c *ManualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.t = c.t.Add(d)
+	c.mu.Unlock()
+}
+
+// Set Moves the clock to t
+func (c *ManualClock) Set(t time.Time) {
+	c.mu.Lock()
+	c.t = t
+	c.mu.Unlock()
+}
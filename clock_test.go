@@ -0,0 +1,48 @@
+package tool
+
+import (
+	"errors"
+	"time"
+)
+
+func (s *ToolTestSuite) TestSetClock() {
+	start := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	clock := NewManualClock(start)
+	SetClock(clock)
+	defer SetClock(nil)
+
+	s.Run("retry sleeps", func() {
+		calls := 0
+		began := time.Now()
+		err := RetryFunc(3, time.Hour, func() error {
+			calls++
+			return errors.New("down")
+		})
+		s.Error(err)
+		s.Equal(4, calls)
+		s.Equal(start.Add(3*time.Hour), clock.Now())
+		s.Less(time.Since(began), time.Minute)
+	})
+
+	s.Run("timestamps", func() {
+		clock.Set(start)
+		bus, err := NewBus[int](nil)
+		s.Require().NoError(err)
+		e, err := bus.Publish(1)
+		s.NoError(err)
+		s.Equal(start, e.Time)
+	})
+
+	s.Run("elapsed", func() {
+		done := ConsoleGroup("frozen")
+		clock.Advance(1500 * time.Millisecond)
+		done()
+		s.Contains(testLog.buf, "frozen done in 1.5s")
+	})
+
+	s.Run("system", func() {
+		SetClock(nil)
+		defer SetClock(clock)
+		s.WithinDuration(time.Now(), clockNow(), time.Second)
+	})
+}
@@ -0,0 +1,33 @@
+package tool
+
+import "sync/atomic"
+
+// CompatLevel Behavior version of APIs whose semantics were corrected over time, see Compat
+type CompatLevel int32
+
+const (
+	// CompatV1 Legacy behavior: Strtr replaces keys one by one in random map order,
+	// so overlapping keys and replacements containing other keys give unpredictable results
+	CompatV1 CompatLevel = iota + 1
+	// CompatV2 Strtr replaces the longest matching keys in a single pass, replaced text is never replaced again
+	CompatV2
+
+	// CompatLatest Newest behavior, used by default
+	CompatLatest = CompatV2
+)
+
+// compatLevel Current CompatLevel, zero means CompatLatest
+var compatLevel atomic.Int32
+
+// Compat Switches corrected APIs to the behavior of level, so existing callers can keep the legacy semantics
+// explicitly while upgrading. Pass CompatLatest to restore the default.
+// Attempt counting of RetryFunc is kept as is, Retry with WithMaxAttempts or WithMaxRetries states it explicitly.
+func Compat(level CompatLevel) {
+	compatLevel.Store(int32(level))
+}
+
+// compatBefore Reports whether behavior older than level is requested
+func compatBefore(level CompatLevel) bool {
+	current := CompatLevel(compatLevel.Load())
+	return current != 0 && current < level
+}
@@ -0,0 +1,69 @@
+package tool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"time"
+)
+
+// DefaultConfigPollInterval Debounce interval of WatchConfig when no interval is given
+const DefaultConfigPollInterval = time.Second
+
+// Validator Implemented by values that are able to check their own consistency
+type Validator interface {
+	Validate() error
+}
+
+// LoadConfig Reads the JSON file at path into a new T, fills missing fields from `default` tags
+// if T is a struct, and validates it, if T implements Validator
+func LoadConfig[T any](path string) (T, error) {
+	var cfg T
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return cfg, err
+	}
+	if err = json.Unmarshal(b, &cfg); err != nil {
+		return cfg, fmt.Errorf("config %s: %w", path, err)
+	}
+	if t := reflect.TypeOf(cfg); t != nil && t.Kind() == reflect.Struct {
+		if err = ApplyDefaults(&cfg); err != nil {
+			return cfg, fmt.Errorf("config %s: %w", path, err)
+		}
+	}
+	if err = validate(&cfg); err != nil {
+		return cfg, fmt.Errorf("config %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// WatchConfig Loads the JSON config at path, passes it to onChange and keeps watching the file until ctx is done.
+// Changes are debounced: the file is re-read only after it stays unchanged for pollInterval,
+// and the new value is passed to onChange only if it decodes and validates, otherwise the error is logged
+// and the previous config stays in effect.
+// Blocks until ctx is done, returns ctx.Err() or the initial load error.
+func WatchConfig[T any](ctx context.Context, path string, onChange func(T), pollInterval ...time.Duration) error {
+	cfg, err := LoadConfig[T](path)
+	if err != nil {
+		return err
+	}
+	onChange(cfg)
+
+	return WatchPaths(ctx, []string{path}, NonZero(append(pollInterval, DefaultConfigPollInterval)...), func([]FileEvent) {
+		cfg, err := LoadConfig[T](path)
+		if err != nil {
+			tooloLog.LogError(err, "config reload skipped")
+			return
+		}
+		onChange(cfg)
+	})
+}
+
+func validate(v any) error {
+	if validator, ok := v.(Validator); ok {
+		return validator.Validate()
+	}
+	return nil
+}
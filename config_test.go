@@ -0,0 +1,85 @@
+package tool
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+type testConfig struct {
+	Name  string
+	Level string `default:"info"`
+}
+
+func (c testConfig) Validate() error {
+	if c.Name == "" {
+		return errors.New("name is required")
+	}
+	return nil
+}
+
+func writeTestFile(s *ToolTestSuite, path, content string, mtime time.Time) {
+	s.Require().NoError(os.WriteFile(path, []byte(content), 0o600))
+	s.Require().NoError(os.Chtimes(path, mtime, mtime))
+}
+
+func (s *ToolTestSuite) TestLoadConfig() {
+	path := filepath.Join(s.T().TempDir(), "config.json")
+	s.Run("missing", func() {
+		_, err := LoadConfig[testConfig](path)
+		s.Error(err)
+	})
+	s.Run("valid", func() {
+		writeTestFile(s, path, `{"Name":"a"}`, time.Now())
+		cfg, err := LoadConfig[testConfig](path)
+		s.NoError(err)
+		s.Equal("a", cfg.Name)
+		s.Equal("info", cfg.Level)
+	})
+	s.Run("invalid", func() {
+		writeTestFile(s, path, `{"Name":""}`, time.Now())
+		_, err := LoadConfig[testConfig](path)
+		s.ErrorContains(err, "name is required")
+	})
+}
+
+func (s *ToolTestSuite) TestWatchConfig() {
+	path := filepath.Join(s.T().TempDir(), "config.json")
+	s.Run("initial error", func() {
+		s.Error(WatchConfig(context.Background(), path, func(testConfig) {}))
+	})
+
+	writeTestFile(s, path, `{"Name":"a"}`, time.Now().Add(-time.Hour))
+	var (
+		mu    sync.Mutex
+		names []string
+	)
+	current := func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), names...)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- WatchConfig(ctx, path, func(cfg testConfig) {
+			mu.Lock()
+			names = append(names, cfg.Name)
+			mu.Unlock()
+		}, 5*time.Millisecond)
+	}()
+
+	s.Eventually(func() bool { return len(current()) == 1 }, time.Second, 5*time.Millisecond)
+	writeTestFile(s, path, `{"Name":""}`, time.Now().Add(-time.Minute))
+	time.Sleep(50 * time.Millisecond)
+	s.Len(current(), 1)
+	writeTestFile(s, path, `{"Name":"b"}`, time.Now())
+	s.Eventually(func() bool { return len(current()) == 2 }, time.Second, 5*time.Millisecond)
+
+	cancel()
+	s.ErrorIs(<-done, context.Canceled)
+	s.Equal([]string{"a", "b"}, current())
+}
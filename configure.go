@@ -0,0 +1,101 @@
+package tool
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+
+	"github.com/iamwavecut/tool/safetool"
+)
+
+// ErrAlreadyConfigured Returned by Configure after the first call
+var ErrAlreadyConfigured = errors.New("defaults are already configured")
+
+// Defaults Package-wide settings applied by Configure. Zero fields, including fields of nested configs,
+// leave the current setting unchanged.
+type Defaults struct {
+	// LogLevel Minimal level of package log messages, see SetLogLevel
+	LogLevel LogLevel
+	// ErrorCallerInfo Enables caller prefix of logged errors, see SetErrorCallerInfo
+	ErrorCallerInfo bool
+	// Retry Base of Retry options
+	Retry RetryConfig
+	// TxRetries Number of WithTx retries after a serialization failure
+	TxRetries int
+	// TxBackoff Delay policy between WithTx retries
+	TxBackoff safetool.Backoff
+	// Download Base of Download options
+	Download DownloadConfig
+	// Cache Base of NewCache options
+	Cache CacheConfig
+	// DumpIndent Indentation of Dump output
+	DumpIndent string
+	// PrintMaxNodes Maximal number of values rendered by SafeSprint and Sdump
+	PrintMaxNodes int
+	// Compat Behavior version of corrected APIs, see Compat
+	Compat CompatLevel
+}
+
+var configureOnce sync.Once
+
+// Configure Applies package-wide defaults, meant to be called once at startup before the package is used,
+// so services share the same behavior without passing options everywhere. Later calls return ErrAlreadyConfigured.
+func Configure(d Defaults) error {
+	err := ErrAlreadyConfigured
+	configureOnce.Do(func() {
+		err = nil
+		if d.LogLevel != LevelDebug {
+			SetLogLevel(d.LogLevel)
+		}
+		if d.ErrorCallerInfo {
+			SetErrorCallerInfo(true)
+		}
+		mergeNonZero(&DefaultRetryConfig, d.Retry)
+		TxRetries = NonZero(d.TxRetries, TxRetries)
+		if d.TxBackoff != nil {
+			TxBackoff = d.TxBackoff
+		}
+		mergeNonZero(&DefaultDownloadConfig, d.Download)
+		mergeNonZero(&DefaultCacheConfig, d.Cache)
+		DumpIndent = NonZero(d.DumpIndent, DumpIndent)
+		PrintMaxNodes = NonZero(d.PrintMaxNodes, PrintMaxNodes)
+		if d.Compat != 0 {
+			Compat(d.Compat)
+		}
+	})
+	return err
+}
+
+// CurrentDefaults Returns settings currently in effect, a convenient base for Configure
+func CurrentDefaults() Defaults {
+	return Defaults{
+		LogLevel:        LogLevel(logLevel.Load()),
+		ErrorCallerInfo: errorCallerInfo.Load(),
+		Retry:           DefaultRetryConfig,
+		TxRetries:       TxRetries,
+		TxBackoff:       TxBackoff,
+		Download:        DefaultDownloadConfig,
+		Cache:           DefaultCacheConfig,
+		DumpIndent:      DumpIndent,
+		PrintMaxNodes:   PrintMaxNodes,
+		Compat:          NonZero(CompatLevel(compatLevel.Load()), CompatLatest),
+	}
+}
+
+// mergeNonZero Copies non-zero fields of src struct into dst, nested structs are merged field by field
+func mergeNonZero[T any](dst *T, src T) {
+	mergeFields(reflect.ValueOf(dst).Elem(), reflect.ValueOf(src))
+}
+
+func mergeFields(dst, src reflect.Value) {
+	for i := 0; i < src.NumField(); i++ {
+		field := src.Field(i)
+		switch {
+		case !dst.Field(i).CanSet() || field.IsZero():
+		case field.Kind() == reflect.Struct:
+			mergeFields(dst.Field(i), field)
+		default:
+			dst.Field(i).Set(field)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package tool
+
+import (
+	"sync"
+	"time"
+
+	"github.com/iamwavecut/tool/safetool"
+)
+
+func (s *ToolTestSuite) TestConfigure() {
+	saved := CurrentDefaults()
+	defer func() {
+		SetLogLevel(saved.LogLevel)
+		SetErrorCallerInfo(saved.ErrorCallerInfo)
+		DefaultRetryConfig, TxRetries, TxBackoff = saved.Retry, saved.TxRetries, saved.TxBackoff
+		DefaultDownloadConfig, DefaultCacheConfig = saved.Download, saved.Cache
+		DumpIndent, PrintMaxNodes = saved.DumpIndent, saved.PrintMaxNodes
+		Compat(saved.Compat)
+		configureOnce = sync.Once{}
+	}()
+
+	backoff := safetool.Constant(time.Millisecond)
+	s.NoError(Configure(Defaults{
+		LogLevel:  LevelWarn,
+		Retry:     RetryConfig{Retries: 1, Backoff: backoff},
+		TxRetries: 5,
+		TxBackoff: backoff,
+		Compat:    CompatV1,
+		Cache:     CacheConfig{MaxSize: 10, TTL: time.Minute},
+	}))
+
+	current := CurrentDefaults()
+	s.Equal(LevelWarn, current.LogLevel)
+	s.Equal(5, current.TxRetries)
+	s.Equal(1, current.Retry.Retries)
+	s.Equal(CompatV1, current.Compat)
+	s.Equal(time.Millisecond, current.TxBackoff.Next(1))
+	s.Equal(CacheConfig{MaxSize: 10, TTL: time.Minute}, NewCache[int, int]().cfg)
+	s.Equal(saved.DumpIndent, current.DumpIndent)
+	s.Equal(saved.PrintMaxNodes, current.PrintMaxNodes)
+	s.Equal(saved.Download.Retries, current.Download.Retries)
+
+	s.ErrorIs(Configure(Defaults{TxRetries: 1}), ErrAlreadyConfigured)
+	s.Equal(5, TxRetries)
+}
+
+func (s *ToolTestSuite) TestConfigureMergesFields() {
+	saved := CurrentDefaults()
+	defer func() {
+		DefaultDownloadConfig, DefaultCacheConfig = saved.Download, saved.Cache
+		DefaultRetryConfig = saved.Retry
+		configureOnce = sync.Once{}
+	}()
+
+	s.NoError(Configure(Defaults{
+		Retry:    RetryConfig{Retries: 2},
+		Cache:    CacheConfig{TTL: time.Minute},
+		Download: DownloadConfig{RateLimit: 1024},
+	}))
+	s.Equal(saved.Cache.MaxSize, DefaultCacheConfig.MaxSize)
+	s.Equal(time.Minute, DefaultCacheConfig.TTL)
+	s.Equal(saved.Download.Retries, DefaultDownloadConfig.Retries)
+	s.Equal(saved.Download.Client, DefaultDownloadConfig.Client)
+	s.NotNil(DefaultDownloadConfig.Backoff)
+	s.Equal(int64(1024), DefaultDownloadConfig.RateLimit)
+	s.Equal(2, DefaultRetryConfig.Retries)
+	s.NotNil(DefaultRetryConfig.Backoff)
+}
@@ -0,0 +1,88 @@
+// Package debughttp HTTP debug endpoints of the process, kept out of tool because importing net/http/pprof
+// registers its handlers on http.DefaultServeMux
+package debughttp
+
+import (
+	"io"
+	"net/http"
+	"net/http/pprof"
+	"strings"
+
+	"github.com/iamwavecut/tool"
+)
+
+// Config Handler settings, adjusted with options
+type Config struct {
+	// LogLevelGuard Reports whether the request may change the log level, nil allows every request
+	LogLevelGuard func(r *http.Request) bool
+}
+
+// WithLogLevelGuard Sets check of requests changing the log level, e.g. a token or remote address check
+func WithLogLevelGuard(guard func(r *http.Request) bool) tool.Option[Config] {
+	return func(c *Config) { c.LogLevelGuard = guard }
+}
+
+// Handler Returns handler serving the debug endpoints of the process:
+//
+//	/debug/pprof/    net/http/pprof profiles
+//	/debug/buildinfo tool.BuildInfo as JSON
+//	/debug/resources tool.ResourceSnapshot as JSON
+//	/debug/loglevel  current log level on GET, PUT or POST with ?level=WARN or a plain text body sets it
+//
+// The handler does no authentication, mount it on an internal listener only.
+// Log level changes are refused with 403 when LogLevelGuard rejects the request.
+func Handler(opts ...tool.Option[Config]) http.Handler {
+	cfg, _ := tool.Build(Config{}, opts...)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.HandleFunc("/debug/buildinfo", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, tool.BuildInfo())
+	})
+	mux.HandleFunc("/debug/resources", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, tool.ResourceSnapshot())
+	})
+	mux.HandleFunc("/debug/loglevel", func(w http.ResponseWriter, r *http.Request) {
+		serveLogLevel(w, r, cfg.LogLevelGuard)
+	})
+	return mux
+}
+
+func serveLogLevel(w http.ResponseWriter, r *http.Request, guard func(r *http.Request) bool) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut, http.MethodPost:
+		if guard != nil && !guard(r) {
+			http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+			return
+		}
+		name := r.URL.Query().Get("level")
+		if name == "" {
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, 64))
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			name = strings.TrimSpace(string(body))
+		}
+		level, err := tool.ParseLogLevel(name)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		tool.SetLogLevel(level)
+	default:
+		w.Header().Set("Allow", "GET, PUT, POST")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	writeJSON(w, map[string]string{"level": tool.GetLogLevel().String()})
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(tool.Jsonify(v).Bytes())
+}
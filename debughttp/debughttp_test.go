@@ -0,0 +1,65 @@
+package debughttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/iamwavecut/tool"
+)
+
+type DebugHTTPTestSuite struct {
+	suite.Suite
+}
+
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(DebugHTTPTestSuite))
+}
+
+func serve(h http.Handler, method, target, body string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(method, target, strings.NewReader(body)))
+	return rec
+}
+
+func (s *DebugHTTPTestSuite) TestHandler() {
+	defer tool.SetLogLevel(tool.LevelDebug)
+	h := Handler()
+
+	rec := serve(h, http.MethodGet, "/debug/pprof/", "")
+	s.Equal(http.StatusOK, rec.Code)
+	s.Contains(rec.Body.String(), "goroutine")
+
+	rec = serve(h, http.MethodGet, "/debug/buildinfo", "")
+	s.Equal("application/json", rec.Header().Get("Content-Type"))
+	s.Contains(rec.Body.String(), `"goVersion"`)
+
+	s.Contains(serve(h, http.MethodGet, "/debug/resources", "").Body.String(), `"goroutines"`)
+
+	s.JSONEq(`{"level":"DEBUG"}`, serve(h, http.MethodGet, "/debug/loglevel", "").Body.String())
+	s.JSONEq(`{"level":"WARN"}`, serve(h, http.MethodPut, "/debug/loglevel?level=warn", "").Body.String())
+	s.JSONEq(`{"level":"ERROR"}`, serve(h, http.MethodPost, "/debug/loglevel", "ERROR\n").Body.String())
+	s.Equal(tool.LevelError, tool.GetLogLevel())
+	s.Equal(http.StatusBadRequest, serve(h, http.MethodPut, "/debug/loglevel", "loud").Code)
+	s.Equal(http.StatusMethodNotAllowed, serve(h, http.MethodDelete, "/debug/loglevel", "").Code)
+}
+
+func (s *DebugHTTPTestSuite) TestLogLevelGuard() {
+	defer tool.SetLogLevel(tool.LevelDebug)
+	h := Handler(WithLogLevelGuard(func(r *http.Request) bool {
+		return r.Header.Get("X-Debug-Token") == "secret"
+	}))
+
+	s.Equal(http.StatusForbidden, serve(h, http.MethodPut, "/debug/loglevel?level=warn", "").Code)
+	s.Equal(tool.LevelDebug, tool.GetLogLevel())
+	s.Equal(http.StatusOK, serve(h, http.MethodGet, "/debug/loglevel", "").Code)
+
+	req := httptest.NewRequest(http.MethodPut, "/debug/loglevel?level=warn", nil)
+	req.Header.Set("X-Debug-Token", "secret")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	s.JSONEq(`{"level":"WARN"}`, rec.Body.String())
+}
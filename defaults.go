@@ -0,0 +1,122 @@
+package tool
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultTag Struct tag holding the default value of a field
+const DefaultTag = "default"
+
+var (
+	durationType        = reflect.TypeOf(time.Duration(0))
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// ApplyDefaults Fills zero-valued fields of the struct pointed by target from their `default:"..."` tags
+// Supports strings, bools, numbers, time.Duration ("1m30s"), encoding.TextUnmarshaler implementations
+// such as time.Time (RFC 3339), pointers and slices (comma separated) of those,
+// and walks untagged nested structs and non-nil struct pointers
+func ApplyDefaults(target any) error {
+	val := reflect.ValueOf(target)
+	if val.Kind() != reflect.Pointer || val.IsNil() || val.Elem().Kind() != reflect.Struct {
+		return errors.New("target must be a non-nil pointer to struct")
+	}
+	return applyDefaults(val.Elem(), "")
+}
+
+func applyDefaults(val reflect.Value, path string) error {
+	for i := 0; i < val.NumField(); i++ {
+		field := val.Field(i)
+		sf := val.Type().Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		name := path + sf.Name
+
+		tag, tagged := sf.Tag.Lookup(DefaultTag)
+		switch {
+		case tagged && !field.IsZero():
+		case tagged:
+			if err := setFromString(field, tag); err != nil {
+				return fmt.Errorf("field %s: %w", name, err)
+			}
+		case field.Kind() == reflect.Struct:
+			if err := applyDefaults(field, name+"."); err != nil {
+				return err
+			}
+		case field.Kind() == reflect.Pointer && !field.IsNil() && field.Elem().Kind() == reflect.Struct:
+			if err := applyDefaults(field.Elem(), name+"."); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// setFromString Parses the string according to the kind of val and sets it
+func setFromString(val reflect.Value, s string) error {
+	if val.CanAddr() && val.Addr().Type().Implements(textUnmarshalerType) {
+		return val.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+	}
+	if val.Type() == durationType {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		val.SetInt(int64(d))
+		return nil
+	}
+
+	switch val.Kind() {
+	case reflect.String:
+		val.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		val.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, val.Type().Bits())
+		if err != nil {
+			return err
+		}
+		val.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, val.Type().Bits())
+		if err != nil {
+			return err
+		}
+		val.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(s, val.Type().Bits())
+		if err != nil {
+			return err
+		}
+		val.SetFloat(n)
+	case reflect.Slice:
+		parts := strings.Split(s, ",")
+		slice := reflect.MakeSlice(val.Type(), len(parts), len(parts))
+		for i, part := range parts {
+			if err := setFromString(slice.Index(i), strings.TrimSpace(part)); err != nil {
+				return err
+			}
+		}
+		val.Set(slice)
+	case reflect.Pointer:
+		ptr := reflect.New(val.Type().Elem())
+		if err := setFromString(ptr.Elem(), s); err != nil {
+			return err
+		}
+		val.Set(ptr)
+	default:
+		return fmt.Errorf("unsupported kind %s", val.Kind())
+	}
+	return nil
+}
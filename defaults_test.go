@@ -0,0 +1,74 @@
+package tool
+
+import (
+	"time"
+)
+
+func (s *ToolTestSuite) TestApplyDefaults() {
+	type nested struct {
+		Retries int `default:"3"`
+	}
+	type target struct {
+		Name     string        `default:"service"`
+		Enabled  bool          `default:"true"`
+		Ratio    float64       `default:"0.5"`
+		Port     uint16        `default:"8080"`
+		Timeout  time.Duration `default:"1m30s"`
+		Hosts    []string      `default:"a, b"`
+		Ports    []int         `default:"1,2"`
+		Limit    *int          `default:"10"`
+		Since    time.Time     `default:"2024-01-02T03:04:05Z"`
+		Until    *time.Time    `default:"2025-01-01T00:00:00Z"`
+		Nested   nested
+		NestedP  *nested
+		Untagged string
+		hidden   string `default:"x"`
+	}
+
+	s.Run("fill zero", func() {
+		t := target{NestedP: &nested{}}
+		s.NoError(ApplyDefaults(&t))
+		s.Equal(target{
+			Name:    "service",
+			Enabled: true,
+			Ratio:   0.5,
+			Port:    8080,
+			Timeout: 90 * time.Second,
+			Hosts:   []string{"a", "b"},
+			Ports:   []int{1, 2},
+			Limit:   Ptr(10),
+			Since:   time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+			Until:   Ptr(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)),
+			Nested:  nested{Retries: 3},
+			NestedP: &nested{Retries: 3},
+		}, t)
+	})
+
+	s.Run("keep set", func() {
+		t := target{Name: "custom", Hosts: []string{"c"}}
+		s.NoError(ApplyDefaults(&t))
+		s.Equal("custom", t.Name)
+		s.Equal([]string{"c"}, t.Hosts)
+		s.Nil(t.NestedP)
+	})
+
+	s.Run("invalid", func() {
+		s.Error(ApplyDefaults(target{}))
+		s.Error(ApplyDefaults((*target)(nil)))
+
+		bad := struct {
+			Timeout time.Duration `default:"soon"`
+		}{}
+		s.ErrorContains(ApplyDefaults(&bad), "field Timeout")
+
+		badTime := struct {
+			Since time.Time `default:"yesterday"`
+		}{}
+		s.ErrorContains(ApplyDefaults(&badTime), "field Since")
+
+		unsupported := struct {
+			M map[string]string `default:"a"`
+		}{}
+		s.ErrorContains(ApplyDefaults(&unsupported), "unsupported kind")
+	})
+}
@@ -0,0 +1,215 @@
+package tool
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DiffContext Number of unchanged lines surrounding changes in a Hunk
+const DiffContext = 3
+
+const (
+	// DiffEqual Line present in both texts
+	DiffEqual DiffOp = ' '
+	// DiffDelete Line present only in the old text
+	DiffDelete DiffOp = '-'
+	// DiffInsert Line present only in the new text
+	DiffInsert DiffOp = '+'
+)
+
+type (
+	// DiffOp Kind of a diff line
+	DiffOp byte
+
+	// DiffLine Line of a Hunk
+	DiffLine struct {
+		Op   DiffOp
+		Text string
+	}
+
+	// Hunk Group of changes with surrounding context, line numbers are 1-based
+	Hunk struct {
+		OldStart, OldLines int
+		NewStart, NewLines int
+		Lines              []DiffLine
+	}
+)
+
+// DiffText Returns line-oriented differences between a and b using Myers algorithm, nil if texts are equal
+func DiffText(a, b string) []Hunk {
+	return diffHunks(myersDiff(splitLines(a), splitLines(b)), DiffContext)
+}
+
+// FormatUnified Formats hunks in unified diff format without file headers
+func FormatUnified(hunks []Hunk) string {
+	var buf strings.Builder
+	for _, h := range hunks {
+		buf.WriteString(fmt.Sprintf("@@ -%s +%s @@\n", unifiedRange(h.OldStart, h.OldLines), unifiedRange(h.NewStart, h.NewLines)))
+		for _, line := range h.Lines {
+			buf.WriteByte(byte(line.Op))
+			buf.WriteString(line.Text)
+			buf.WriteByte('\n')
+		}
+	}
+	return buf.String()
+}
+
+// ConsoleDiff Prints unified diff of a and b prefixed with the caller position, great to debug drifting output
+func ConsoleDiff(a, b string) {
+	prefix, err := callerPrefix(2)
+	if err != nil {
+		tooloLog.LogError(err)
+		return
+	}
+	hunks := DiffText(a, b)
+	if len(hunks) == 0 {
+		tooloLog.LogText(prefix + " <no diff>")
+		return
+	}
+	tooloLog.LogText(prefix + "\n" + strings.TrimSuffix(FormatUnified(hunks), "\n"))
+}
+
+func unifiedRange(start, lines int) string {
+	if lines == 0 {
+		start-- // empty range refers to the line before
+	}
+	if lines == 1 {
+		return fmt.Sprint(start)
+	}
+	return fmt.Sprintf("%d,%d", start, lines)
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// myersDiff Returns the shortest edit script turning a into b
+func myersDiff(a, b []string) []DiffLine {
+	n, m := len(a), len(b)
+	max := n + m
+	offset := max + 1
+	v := make([]int, 2*max+3)
+	var trace [][]int
+
+search:
+	for d := 0; d <= max; d++ {
+		trace = append(trace, append([]int(nil), v...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || k != d && v[offset+k-1] < v[offset+k+1] {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				break search
+			}
+		}
+	}
+
+	script := make([]DiffLine, 0, max)
+	x, y := n, m
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		k := x - y
+		prevK := k - 1
+		if k == -d || k != d && v[offset+k-1] < v[offset+k+1] {
+			prevK = k + 1
+		}
+		prevX := v[offset+prevK]
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			script = append(script, DiffLine{Op: DiffEqual, Text: a[x-1]})
+			x, y = x-1, y-1
+		}
+		if d > 0 {
+			if x == prevX {
+				script = append(script, DiffLine{Op: DiffInsert, Text: b[y-1]})
+			} else {
+				script = append(script, DiffLine{Op: DiffDelete, Text: a[x-1]})
+			}
+		}
+		x, y = prevX, prevY
+	}
+
+	for i, j := 0, len(script)-1; i < j; i, j = i+1, j-1 {
+		script[i], script[j] = script[j], script[i]
+	}
+	return script
+}
+
+// diffHunks Groups the edit script into hunks keeping context lines around changes
+func diffHunks(script []DiffLine, context int) []Hunk {
+	var (
+		hunks    []Hunk
+		current  *Hunk
+		oldLine  = 1
+		newLine  = 1
+		lastEdit = -1
+	)
+	for i, line := range script {
+		if line.Op != DiffEqual {
+			if current == nil || i-lastEdit > 2*context {
+				if current != nil {
+					hunks = append(hunks, withTrailingContext(*current, script[lastEdit+1:], context))
+				}
+				start := i - context
+				if start < 0 {
+					start = 0
+				}
+				current = &Hunk{OldStart: oldLine, NewStart: newLine}
+				for _, ctx := range script[start:i] {
+					current.Lines = append(current.Lines, ctx)
+					current.OldStart--
+					current.NewStart--
+					current.OldLines++
+					current.NewLines++
+				}
+			} else {
+				current.Lines = append(current.Lines, script[lastEdit+1:i]...)
+				current.OldLines += i - lastEdit - 1
+				current.NewLines += i - lastEdit - 1
+			}
+			current.Lines = append(current.Lines, line)
+			if line.Op == DiffDelete {
+				current.OldLines++
+			} else {
+				current.NewLines++
+			}
+			lastEdit = i
+		}
+
+		switch line.Op {
+		case DiffEqual:
+			oldLine++
+			newLine++
+		case DiffDelete:
+			oldLine++
+		case DiffInsert:
+			newLine++
+		}
+	}
+	if current != nil {
+		hunks = append(hunks, withTrailingContext(*current, script[lastEdit+1:], context))
+	}
+	return hunks
+}
+
+func withTrailingContext(h Hunk, rest []DiffLine, context int) Hunk {
+	if len(rest) > context {
+		rest = rest[:context]
+	}
+	h.Lines = append(h.Lines, rest...)
+	h.OldLines += len(rest)
+	h.NewLines += len(rest)
+	return h
+}
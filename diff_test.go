@@ -0,0 +1,69 @@
+package tool
+
+import (
+	"fmt"
+	"strings"
+)
+
+func (s *ToolTestSuite) TestDiffText() {
+	s.Run("equal", func() {
+		s.Nil(DiffText("a\nb\n", "a\nb\n"))
+		s.Nil(DiffText("", ""))
+	})
+
+	s.Run("single change", func() {
+		hunks := DiffText("a\nb\nc\n", "a\nB\nc\n")
+		s.Require().Len(hunks, 1)
+		s.Equal(Hunk{
+			OldStart: 1, OldLines: 3,
+			NewStart: 1, NewLines: 3,
+			Lines: []DiffLine{
+				{DiffEqual, "a"},
+				{DiffDelete, "b"},
+				{DiffInsert, "B"},
+				{DiffEqual, "c"},
+			},
+		}, hunks[0])
+		s.Equal("@@ -1,3 +1,3 @@\n a\n-b\n+B\n c\n", FormatUnified(hunks))
+	})
+
+	s.Run("from and to empty", func() {
+		s.Equal("@@ -0,0 +1,2 @@\n+a\n+b\n", FormatUnified(DiffText("", "a\nb")))
+		s.Equal("@@ -1 +0,0 @@\n-a\n", FormatUnified(DiffText("a\n", "")))
+	})
+
+	s.Run("separate hunks", func() {
+		var old, changed []string
+		for i := 1; i <= 20; i++ {
+			old = append(old, fmt.Sprint(i))
+			changed = append(changed, fmt.Sprint(i))
+		}
+		changed[1] = "two"
+		changed = append(changed[:15], changed[16:]...)
+
+		hunks := DiffText(strings.Join(old, "\n"), strings.Join(changed, "\n"))
+		s.Require().Len(hunks, 2)
+		s.Equal(
+			"@@ -1,5 +1,5 @@\n 1\n-2\n+two\n 3\n 4\n 5\n"+
+				"@@ -13,7 +13,6 @@\n 13\n 14\n 15\n-16\n 17\n 18\n 19\n",
+			FormatUnified(hunks),
+		)
+	})
+
+	s.Run("merged hunks", func() {
+		hunks := DiffText("1\n2\n3\n4\n5\n6\n7\n8\n", "1\nx\n3\n4\n5\n6\ny\n8\n")
+		s.Require().Len(hunks, 1)
+		s.Equal(1, hunks[0].OldStart)
+		s.Equal(8, hunks[0].OldLines)
+		s.Equal(8, hunks[0].NewLines)
+	})
+}
+
+func (s *ToolTestSuite) TestConsoleDiff() {
+	ConsoleDiff("a\nb\n", "a\nc\n")
+	s.Equal("[github.com/iamwavecut/tool:63]>\n@@ -1,2 +1,2 @@\n a\n-b\n+c\n", testLog.buf)
+
+	testLog.buf = ""
+	ConsoleDiff("a", "a")
+	s.Equal("[github.com/iamwavecut/tool:67]> <no diff>\n", testLog.buf)
+}
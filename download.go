@@ -0,0 +1,171 @@
+package tool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/iamwavecut/tool/safetool"
+)
+
+// DownloadConfig Download settings, adjusted with options
+type DownloadConfig struct {
+	Client *http.Client
+	// Retries Number of retries after the first failed attempt, <0 retries until ctx is done
+	Retries int
+	// Backoff Delay policy between attempts
+	Backoff safetool.Backoff
+	// Checksum Expected digest in VerifyChecksum format, empty to skip verification
+	Checksum string
+	// Progress Called after every written chunk, total is -1 when unknown
+	Progress func(done, total int64)
+	// RateLimit Maximum average speed in bytes per second, 0 for unlimited
+	RateLimit int64
+}
+
+// DefaultDownloadConfig Used as the base of Download options
+var DefaultDownloadConfig = DownloadConfig{
+	Client:  http.DefaultClient,
+	Retries: 3,
+	Backoff: safetool.ExponentialWithJitter(time.Second, 30*time.Second, 0.2),
+}
+
+// WithDownloadRetries Sets number of retries and delay policy between attempts
+func WithDownloadRetries(retries int, backoff safetool.Backoff) Option[DownloadConfig] {
+	return func(c *DownloadConfig) { c.Retries, c.Backoff = retries, backoff }
+}
+
+// WithDownloadChecksum Verifies downloaded file against expected digest before moving it in place
+func WithDownloadChecksum(expected string) Option[DownloadConfig] {
+	return func(c *DownloadConfig) { c.Checksum = expected }
+}
+
+// WithDownloadProgress Sets progress callback
+func WithDownloadProgress(fn func(done, total int64)) Option[DownloadConfig] {
+	return func(c *DownloadConfig) { c.Progress = fn }
+}
+
+// WithDownloadRateLimit Limits average download speed to bytesPerSec
+func WithDownloadRateLimit(bytesPerSec int64) Option[DownloadConfig] {
+	return func(c *DownloadConfig) { c.RateLimit = bytesPerSec }
+}
+
+// WithDownloadClient Sets HTTP client
+func WithDownloadClient(client *http.Client) Option[DownloadConfig] {
+	return func(c *DownloadConfig) { c.Client = client }
+}
+
+// Download Fetches url into dest file.
+// Data is written to dest+".part" first, failed attempts are retried resuming the partial file with Range requests
+// where the server supports them. The file is verified and atomically renamed to dest only when complete.
+func Download(ctx context.Context, url, dest string, opts ...Option[DownloadConfig]) error {
+	cfg, err := Build(DefaultDownloadConfig, opts...)
+	if err != nil {
+		return err
+	}
+	part := dest + ".part"
+
+	err = safetool.RetryIf(ctx, cfg.Retries, cfg.Backoff, func(ctx context.Context) error {
+		if err := downloadPart(ctx, cfg, url, part); err != nil {
+			tooloLog.LogError(err, "download "+url)
+			return err
+		}
+		if cfg.Checksum == "" {
+			return nil
+		}
+		if err := VerifyChecksum(part, cfg.Checksum); err != nil {
+			_ = os.Remove(part)
+			return err
+		}
+		return nil
+	}, isRetryableDownloadError)
+	if err != nil {
+		return err
+	}
+	return os.Rename(part, dest)
+}
+
+func downloadPart(ctx context.Context, cfg DownloadConfig, url, part string) (err error) {
+	var offset int64
+	if fi, err := os.Stat(part); err == nil {
+		offset = fi.Size()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	if offset > 0 {
+		req.Header.Set("Range", "bytes="+strconv.FormatInt(offset, 10)+"-")
+	}
+	resp, err := cfg.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	flags := os.O_CREATE | os.O_WRONLY
+	switch {
+	case resp.StatusCode == http.StatusPartialContent && offset > 0:
+		flags |= os.O_APPEND
+	case resp.StatusCode == http.StatusRequestedRangeNotSatisfiable && offset > 0:
+		_ = os.Remove(part) // partial file is stale or bigger than the resource, start over
+		return fmt.Errorf("range %d- not satisfiable", offset)
+	case resp.StatusCode >= 200 && resp.StatusCode <= 299:
+		flags |= os.O_TRUNC
+		offset = 0
+	default:
+		return newHTTPError(resp)
+	}
+
+	total := int64(-1)
+	if resp.ContentLength >= 0 {
+		total = offset + resp.ContentLength
+	}
+	f, err := os.OpenFile(part, flags, 0o644)
+	if err != nil {
+		return err
+	}
+	defer closeWith(f, &err)
+
+	var w io.Writer = f
+	if cfg.Progress != nil {
+		w = &progressWriter{w: f, done: offset, total: total, fn: cfg.Progress}
+	}
+	_, err = io.Copy(w, ThrottledReader(resp.Body, cfg.RateLimit))
+	return err
+}
+
+type progressWriter struct {
+	w     io.Writer
+	done  int64
+	total int64
+	fn    func(done, total int64)
+}
+
+func (p *progressWriter) Write(b []byte) (int, error) {
+	n, err := p.w.Write(b)
+	p.done += int64(n)
+	p.fn(p.done, p.total)
+	return n, err
+}
+
+// isRetryableDownloadError Client errors won't go away on retry, except timeouts and rate limiting
+func isRetryableDownloadError(err error) bool {
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) {
+		return true
+	}
+	switch code := httpErr.StatusCode; {
+	case code == http.StatusRequestTimeout, code == http.StatusTooManyRequests:
+		return true
+	case code >= 400 && code <= 499:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,100 @@
+package tool
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/iamwavecut/tool/safetool"
+)
+
+func (s *ToolTestSuite) TestDownload() {
+	content := bytes.Repeat([]byte("0123456789"), 1000)
+	sum := sha256.Sum256(content)
+	var (
+		mu     sync.Mutex
+		ranges []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		ranges = append(ranges, r.Header.Get("Range"))
+		first := len(ranges) == 1
+		mu.Unlock()
+
+		if r.URL.Path == "/missing" {
+			http.NotFound(w, r)
+			return
+		}
+		if first {
+			// drop the connection halfway through
+			w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+			_, _ = w.Write(content[:len(content)/2])
+			w.(http.Flusher).Flush()
+			panic(http.ErrAbortHandler)
+		}
+		http.ServeContent(w, r, "file.bin", time.Time{}, bytes.NewReader(content))
+	}))
+	defer srv.Close()
+	dir := s.T().TempDir()
+
+	s.Run("resume", func() {
+		dest := filepath.Join(dir, "file.bin")
+		var lastDone, lastTotal int64
+		err := Download(context.Background(), srv.URL+"/file.bin", dest,
+			WithDownloadRetries(2, safetool.Constant(time.Millisecond)),
+			WithDownloadChecksum(hex.EncodeToString(sum[:])),
+			WithDownloadProgress(func(done, total int64) { lastDone, lastTotal = done, total }),
+		)
+		s.Require().NoError(err)
+		s.Equal(content, MustReturn(os.ReadFile(dest)))
+		s.NoFileExists(dest + ".part")
+		s.Equal(int64(len(content)), lastDone)
+		s.Equal(int64(len(content)), lastTotal)
+		s.Equal([]string{"", "bytes=" + strconv.Itoa(len(content)/2) + "-"}, ranges)
+	})
+
+	s.Run("checksum mismatch", func() {
+		dest := filepath.Join(dir, "bad.bin")
+		err := Download(context.Background(), srv.URL+"/file.bin", dest,
+			WithDownloadRetries(0, safetool.Constant(0)),
+			WithDownloadChecksum("sha256:00"),
+		)
+		s.ErrorIs(err, ErrChecksumMismatch)
+		s.NoFileExists(dest)
+		s.NoFileExists(dest + ".part")
+	})
+
+	s.Run("http error", func() {
+		mu.Lock()
+		ranges = nil
+		mu.Unlock()
+		err := Download(context.Background(), srv.URL+"/missing", filepath.Join(dir, "missing"),
+			WithDownloadRetries(3, safetool.Constant(0)),
+			WithDownloadClient(srv.Client()),
+		)
+		var httpErr *HTTPError
+		s.ErrorAs(err, &httpErr)
+		s.Equal(http.StatusNotFound, httpErr.StatusCode)
+		s.Len(ranges, 1, "client errors are not retried")
+	})
+
+	s.Run("rate limit", func() {
+		start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		clock := NewManualClock(start)
+		SetClock(clock)
+		defer SetClock(nil)
+
+		dest := filepath.Join(dir, "slow.bin")
+		s.Require().NoError(Download(context.Background(), srv.URL+"/file.bin", dest, WithDownloadRateLimit(2500)))
+		s.Equal(content, MustReturn(os.ReadFile(dest)))
+		s.Equal(4*time.Second, clock.Now().Sub(start))
+	})
+}
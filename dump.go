@@ -0,0 +1,280 @@
+package tool
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DumpIndent Indentation of Dump output
+var DumpIndent = "  "
+
+// DumpConfig Sdump settings, adjusted with options
+type DumpConfig struct {
+	// Unexported Includes unexported struct fields
+	Unexported bool
+	// Indent Puts every field and element on its own line indented with the string, single line output if empty
+	Indent string
+}
+
+// WithDumpUnexported Includes unexported struct fields into the Sdump output
+func WithDumpUnexported() Option[DumpConfig] {
+	return func(c *DumpConfig) { c.Unexported = true }
+}
+
+// WithDumpIndent Renders nested values on separate lines indented with indent
+func WithDumpIndent(indent string) Option[DumpConfig] {
+	return func(c *DumpConfig) { c.Indent = indent }
+}
+
+// Dump Prints indented Sdump of every argument, including unexported fields, prefixed like Console output
+func Dump(obj ...any) {
+	prefix, err := callerPrefix(2)
+	if err != nil {
+		tooloLog.LogError(err)
+		return
+	}
+	dumps := make([]string, len(obj))
+	for i, v := range obj {
+		dumps[i] = Sdump(v, WithDumpUnexported(), WithDumpIndent(DumpIndent))
+	}
+	tooloLog.LogText(prefix + " " + strings.Join(dumps, "\n"))
+}
+
+// Sdump Returns Go-syntax-like representation of v, e.g. `pkg.Type{Field: "value", Ptr: &pkg.Inner{N: 1}}`.
+// Map keys are sorted, values having non-default types inside interfaces are annotated, e.g. `int64(1)`,
+// pointers leading back to a value being dumped are rendered as `<cycle>`, and values past PrintMaxNodes as "…".
+func Sdump(v any, opts ...Option[DumpConfig]) string {
+	cfg, _ := Build(DumpConfig{}, opts...)
+	budget := PrintMaxNodes
+	d := &dumper{cfg: cfg, visited: map[dumpRef]bool{}, budget: &budget}
+	d.value(reflect.ValueOf(v), true)
+	return d.buf.String()
+}
+
+// dumpRef Identifies a referenced value, the type tells apart a struct and its first field sharing the address
+type dumpRef struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+type dumper struct {
+	cfg     DumpConfig
+	buf     strings.Builder
+	visited map[dumpRef]bool
+	depth   int
+	// budget Number of values left to render, shared with nested dumpers
+	budget *int
+}
+
+// value Writes val, annotated marks values reached through an interface, which need their type spelled out
+func (d *dumper) value(val reflect.Value, annotated bool) {
+	if *d.budget <= 0 {
+		if *d.budget == 0 {
+			d.buf.WriteString("…")
+			*d.budget--
+		}
+		return
+	}
+	*d.budget--
+
+	if !val.IsValid() {
+		d.buf.WriteString("nil")
+		return
+	}
+	t := val.Type()
+
+	switch val.Kind() {
+	case reflect.Interface:
+		if val.IsNil() {
+			d.buf.WriteString("nil")
+			return
+		}
+		d.value(val.Elem(), true)
+
+	case reflect.Ptr:
+		if val.IsNil() {
+			d.nilOf(t)
+			return
+		}
+		if d.enter(val) {
+			return
+		}
+		defer d.leave(val)
+		d.buf.WriteByte('&')
+		d.value(val.Elem(), true)
+
+	case reflect.Struct:
+		fields := make([]int, 0, t.NumField())
+		for i := 0; i < t.NumField(); i++ {
+			if t.Field(i).PkgPath == "" || d.cfg.Unexported {
+				fields = append(fields, i)
+			}
+		}
+		d.buf.WriteString(t.String())
+		d.items(len(fields), func(i int) {
+			d.buf.WriteString(t.Field(fields[i]).Name)
+			d.buf.WriteString(": ")
+			d.value(val.Field(fields[i]), false)
+		})
+
+	case reflect.Slice:
+		if val.IsNil() {
+			d.nilOf(t)
+			return
+		}
+		if d.enter(val) {
+			return
+		}
+		defer d.leave(val)
+		d.list(val)
+
+	case reflect.Array:
+		d.list(val)
+
+	case reflect.Map:
+		if val.IsNil() {
+			d.nilOf(t)
+			return
+		}
+		if d.enter(val) {
+			return
+		}
+		defer d.leave(val)
+		d.mapping(val)
+
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		if val.IsNil() {
+			d.nilOf(t)
+			return
+		}
+		d.buf.WriteString("(" + t.String() + ")(0x" + strconv.FormatUint(uint64(val.Pointer()), 16) + ")")
+
+	default:
+		d.scalar(val, annotated)
+	}
+}
+
+func (d *dumper) list(val reflect.Value) {
+	d.buf.WriteString(val.Type().String())
+	d.items(val.Len(), func(i int) {
+		d.value(val.Index(i), false)
+	})
+}
+
+func (d *dumper) mapping(val reflect.Value) {
+	type entry struct{ key, value string }
+	entries := make([]entry, 0, val.Len())
+	iter := val.MapRange()
+	for iter.Next() {
+		key := &dumper{cfg: d.cfg, visited: d.visited, depth: d.depth + 1, budget: d.budget}
+		key.value(iter.Key(), false)
+		elem := &dumper{cfg: d.cfg, visited: d.visited, depth: d.depth + 1, budget: d.budget}
+		elem.value(iter.Value(), false)
+		entries = append(entries, entry{key.buf.String(), elem.buf.String()})
+		if *d.budget < 0 {
+			break
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].key < entries[j].key })
+
+	d.buf.WriteString(val.Type().String())
+	d.items(len(entries), func(i int) {
+		d.buf.WriteString(entries[i].key)
+		if entries[i].value != "" {
+			d.buf.WriteString(": ")
+			d.buf.WriteString(entries[i].value)
+		}
+	})
+}
+
+// items Writes braced list of n items, one per line when indenting
+func (d *dumper) items(n int, item func(i int)) {
+	d.buf.WriteByte('{')
+	if n == 0 {
+		d.buf.WriteByte('}')
+		return
+	}
+	d.depth++
+	for i := 0; i < n && *d.budget >= 0; i++ {
+		switch {
+		case d.cfg.Indent != "":
+			d.buf.WriteString("\n" + strings.Repeat(d.cfg.Indent, d.depth))
+		case i > 0:
+			d.buf.WriteString(", ")
+		}
+		item(i)
+		if d.cfg.Indent != "" {
+			d.buf.WriteByte(',')
+		}
+	}
+	d.depth--
+	if d.cfg.Indent != "" {
+		d.buf.WriteString("\n" + strings.Repeat(d.cfg.Indent, d.depth))
+	}
+	d.buf.WriteByte('}')
+}
+
+func (d *dumper) scalar(val reflect.Value, annotated bool) {
+	var (
+		s     string
+		force bool
+	)
+	switch val.Kind() {
+	case reflect.Bool:
+		s = strconv.FormatBool(val.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		s = strconv.FormatInt(val.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		s = strconv.FormatUint(val.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		s = strconv.FormatFloat(val.Float(), 'g', -1, val.Type().Bits())
+		if annotated && !strings.ContainsAny(s, ".eIN") {
+			force = true // 1.0 would read as int otherwise
+		}
+	case reflect.Complex64, reflect.Complex128:
+		s = strconv.FormatComplex(val.Complex(), 'g', -1, val.Type().Bits())
+		force = true
+	case reflect.String:
+		s = strconv.Quote(val.String())
+	}
+
+	if force || annotated && !isDefaultType(val.Type()) {
+		s = val.Type().String() + "(" + s + ")"
+	}
+	d.buf.WriteString(s)
+}
+
+func (d *dumper) nilOf(t reflect.Type) {
+	switch t.Kind() {
+	case reflect.Slice, reflect.Map:
+		d.buf.WriteString(t.String() + "(nil)")
+	default:
+		d.buf.WriteString("(" + t.String() + ")(nil)")
+	}
+}
+
+// enter Marks referenced value as being dumped, returns true and writes cycle marker if it already is
+func (d *dumper) enter(val reflect.Value) bool {
+	ref := dumpRef{val.Pointer(), val.Type()}
+	if d.visited[ref] {
+		d.buf.WriteString("<cycle>")
+		return true
+	}
+	d.visited[ref] = true
+	return false
+}
+
+func (d *dumper) leave(val reflect.Value) {
+	delete(d.visited, dumpRef{val.Pointer(), val.Type()})
+}
+
+// isDefaultType Reports whether t is the default type of an untyped constant of its kind
+func isDefaultType(t reflect.Type) bool {
+	switch t {
+	case reflect.TypeOf(0), reflect.TypeOf(""), reflect.TypeOf(false), reflect.TypeOf(float64(0)):
+		return true
+	}
+	return false
+}
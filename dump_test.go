@@ -0,0 +1,83 @@
+package tool
+
+import "time"
+
+type dumpInner struct {
+	N    int
+	Tags []string
+}
+
+type dumpNode struct {
+	Name   string
+	Next   *dumpNode
+	secret string
+}
+
+func (s *ToolTestSuite) TestSdump() {
+	s.Run("scalars", func() {
+		s.Equal("nil", Sdump(nil))
+		s.Equal("1", Sdump(1))
+		s.Equal("int64(1)", Sdump(int64(1)))
+		s.Equal("float64(2)", Sdump(2.0))
+		s.Equal("2.5", Sdump(2.5))
+		s.Equal(`"a\n"`, Sdump("a\n"))
+		s.Equal(`tool.Varchar("v")`, Sdump(Varchar("v")))
+		s.Equal("time.Duration(1000000000)", Sdump(time.Second))
+	})
+
+	s.Run("composite", func() {
+		v := struct {
+			Inner  *dumpInner
+			Nil    *dumpInner
+			Map    map[string]any
+			Empty  []int
+			Values [2]uint8
+		}{
+			Inner: &dumpInner{N: 1, Tags: []string{"a", "b"}},
+			Map:   map[string]any{"b": int32(2), "a": 1, "c": nil},
+			Empty: []int{},
+		}
+		s.Equal(
+			`struct { Inner *tool.dumpInner; Nil *tool.dumpInner; Map map[string]interface {}; Empty []int; Values [2]uint8 }{`+
+				`Inner: &tool.dumpInner{N: 1, Tags: []string{"a", "b"}}, Nil: (*tool.dumpInner)(nil), `+
+				`Map: map[string]interface {}{"a": 1, "b": int32(2), "c": nil}, Empty: []int{}, Values: [2]uint8{0, 0}}`,
+			Sdump(v),
+		)
+		s.Equal("[]int(nil)", Sdump([]int(nil)))
+		s.Equal("[]interface {}{1, int8(2), &3, (func())(nil)}", Sdump([]any{1, int8(2), Ptr(3), (func())(nil)}))
+	})
+
+	s.Run("unexported and cycles", func() {
+		a := &dumpNode{Name: "a", secret: "s"}
+		a.Next = &dumpNode{Name: "b", Next: a}
+		s.Equal(`&tool.dumpNode{Name: "a", Next: &tool.dumpNode{Name: "b", Next: <cycle>}}`, Sdump(a))
+		s.Equal(
+			`&tool.dumpNode{Name: "a", Next: &tool.dumpNode{Name: "b", Next: <cycle>, secret: ""}, secret: "s"}`,
+			Sdump(a, WithDumpUnexported()),
+		)
+
+		shared := &dumpInner{N: 1}
+		s.Equal(
+			"[]*tool.dumpInner{&tool.dumpInner{N: 1, Tags: []string(nil)}, &tool.dumpInner{N: 1, Tags: []string(nil)}}",
+			Sdump([]*dumpInner{shared, shared}),
+		)
+	})
+}
+
+func (s *ToolTestSuite) TestDump() {
+	node := &dumpNode{Name: "a", secret: "s"}
+	node.Next = node
+	Dump(node, map[string][]int{"k": {1}, "e": {}})
+	s.Equal(`[github.com/iamwavecut/tool:70]> &tool.dumpNode{
+  Name: "a",
+  Next: <cycle>,
+  secret: "s",
+}
+map[string][]int{
+  "e": []int{},
+  "k": []int{
+    1,
+  },
+}
+`, testLog.buf)
+}
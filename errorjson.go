@@ -0,0 +1,78 @@
+package tool
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// maxErrorJSONDepth Limits cause nesting of JSONError output, guards against self-wrapping errors
+const maxErrorJSONDepth = 32
+
+type (
+	// JSONError Error wrapper, itself an error, serialized as {"message": ..., "type": ..., "causes": [...]} with the unwrap chain
+	// expanded into causes, use it for error fields of marshaled structs, plain error values are encoded as {}
+	JSONError struct {
+		Err error
+	}
+
+	errorNode struct {
+		Message string       `json:"message"`
+		Type    string       `json:"type"`
+		Causes  []*errorNode `json:"causes,omitempty"`
+	}
+)
+
+// ErrorJSON Returns JSON representation of err with its causes, "null" for nil
+func ErrorJSON(err error) Varchar {
+	return Jsonify(JSONError{Err: err})
+}
+
+// MarshalJSON Implements json.Marshaler
+func (e JSONError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(newErrorNode(e.Err, 0))
+}
+
+// Error Implements error, returns the message of the wrapped error
+func (e JSONError) Error() string {
+	if e.Err == nil {
+		return ""
+	}
+	return e.Err.Error()
+}
+
+// Unwrap Returns the wrapped error
+func (e JSONError) Unwrap() error {
+	return e.Err
+}
+
+// MarshalText Implements encoding.TextMarshaler, returns the error message
+func (e JSONError) MarshalText() ([]byte, error) {
+	if e.Err == nil {
+		return nil, nil
+	}
+	return []byte(e.Err.Error()), nil
+}
+
+func newErrorNode(err error, depth int) *errorNode {
+	if err == nil {
+		return nil
+	}
+	node := &errorNode{Message: err.Error(), Type: fmt.Sprintf("%T", err)}
+	if depth >= maxErrorJSONDepth {
+		return node
+	}
+
+	var causes []error
+	switch wrapped := err.(type) {
+	case interface{ Unwrap() error }:
+		causes = []error{wrapped.Unwrap()}
+	case interface{ Unwrap() []error }:
+		causes = wrapped.Unwrap()
+	}
+	for _, cause := range causes {
+		if child := newErrorNode(cause, depth+1); child != nil {
+			node.Causes = append(node.Causes, child)
+		}
+	}
+	return node
+}
@@ -0,0 +1,49 @@
+package tool
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"github.com/iamwavecut/tool/safetool"
+)
+
+func (s *ToolTestSuite) TestErrorJSON() {
+	s.Run("chain", func() {
+		err := fmt.Errorf("load: %w", &fs.PathError{Op: "open", Path: "cfg", Err: fs.ErrNotExist})
+		s.JSONEq(`{
+			"message": "load: open cfg: file does not exist",
+			"type": "*fmt.wrapError",
+			"causes": [{
+				"message": "open cfg: file does not exist",
+				"type": "*fs.PathError",
+				"causes": [{"message": "file does not exist", "type": "*errors.errorString"}]
+			}]
+		}`, ErrorJSON(err).String())
+	})
+
+	s.Run("multi and nil", func() {
+		err := safetool.CombineErrors(errors.New("a"), errors.New("b"))
+		s.JSONEq(`{"message": "a\nb", "type": "*safetool.MultiError", "causes": [
+			{"message": "a", "type": "*errors.errorString"},
+			{"message": "b", "type": "*errors.errorString"}
+		]}`, ErrorJSON(err).String())
+		s.Equal(Varchar("null"), ErrorJSON(nil))
+	})
+
+	s.Run("embedded", func() {
+		type result struct {
+			OK  bool      `json:"ok"`
+			Err JSONError `json:"err"`
+		}
+		s.JSONEq(`{"ok": false, "err": {"message": "x", "type": "*errors.errorString"}}`,
+			Jsonify(result{Err: JSONError{errors.New("x")}}).String())
+	})
+
+	s.Run("is error", func() {
+		var err error = JSONError{Err: fmt.Errorf("load: %w", fs.ErrNotExist)}
+		s.Equal("load: file does not exist", err.Error())
+		s.ErrorIs(err, fs.ErrNotExist)
+		s.Empty(JSONError{}.Error())
+	})
+}
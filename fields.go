@@ -0,0 +1,50 @@
+package tool
+
+import (
+	"log/slog"
+	"strconv"
+	"strings"
+)
+
+// Fields Returns typed key-value pairs built from alternating keys and values, slog.Attr items are taken as is.
+// A value without a string key gets the "!BADKEY" key, as with slog.
+func Fields(kv ...any) []slog.Attr {
+	return slog.Group("", kv...).Value.Group()
+}
+
+// ConsoleKV Prints msg with key-value fields, see Fields. With SetSlogLogger the fields are emitted
+// as record attributes, e.g. proper JSON fields, otherwise as key=value pairs following the message.
+func ConsoleKV(msg string, kv ...any) {
+	if !LevelInfo.enabled() {
+		return
+	}
+	if tooloLog.slog != nil {
+		tooloLog.logAttrs(slog.LevelInfo, msg, Fields(kv...)...)
+		return
+	}
+	prefix, err := callerPrefix(2)
+	if err != nil {
+		tooloLog.LogError(err)
+		return
+	}
+	var buf strings.Builder
+	buf.WriteString(prefix + " " + msg)
+	writeFields(&buf, "", Fields(kv...))
+	tooloLog.LogText(strings.ReplaceAll(strings.ReplaceAll(buf.String(), "\r", "\\r"), "\n", "\\n"))
+}
+
+// writeFields Writes attrs as space separated key=value pairs, group keys are joined with dots
+func writeFields(buf *strings.Builder, prefix string, attrs []slog.Attr) {
+	for _, attr := range attrs {
+		val := attr.Value.Resolve()
+		if val.Kind() == slog.KindGroup {
+			writeFields(buf, prefix+attr.Key+".", val.Group())
+			continue
+		}
+		str := SafeSprint(val.Any())
+		if str == "" || strings.ContainsAny(str, " =\"") {
+			str = strconv.Quote(str)
+		}
+		buf.WriteString(" " + prefix + attr.Key + "=" + str)
+	}
+}
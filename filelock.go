@@ -0,0 +1,48 @@
+package tool
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrLocked Returned by WithFileLock when the lock is held by another process
+var ErrLocked = errors.New("lock is held by another process")
+
+// StaleLockTimeout Age after which a lock file without heartbeat is considered abandoned.
+// Only used on platforms without advisory locks, elsewhere the OS releases the lock of a dead process.
+var StaleLockTimeout = time.Minute
+
+// WithFileLock Runs f only if the exclusive lock on path is acquired, returns ErrLocked otherwise.
+// Lock file keeps the PID of the holder, lock is released when f returns or panics.
+func WithFileLock(path string, f func() error) (err error) {
+	unlock, err := lockFile(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if unlockErr := unlock(); err == nil {
+			err = unlockErr
+		}
+	}()
+	return f()
+}
+
+func lockedError(path string) error {
+	b, _ := os.ReadFile(path)
+	if pid := strings.TrimSpace(string(b)); pid != "" {
+		return fmt.Errorf("%w: %s (pid %s)", ErrLocked, path, pid)
+	}
+	return fmt.Errorf("%w: %s", ErrLocked, path)
+}
+
+func writePID(f *os.File) error {
+	if err := f.Truncate(0); err != nil {
+		return err
+	}
+	_, err := f.WriteAt([]byte(strconv.Itoa(os.Getpid())+"\n"), 0)
+	return err
+}
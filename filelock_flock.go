@@ -0,0 +1,38 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+
+package tool
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+func lockFile(path string) (unlock func() error, err error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	if err = syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+		_ = f.Close()
+		if errors.Is(err, syscall.EWOULDBLOCK) {
+			return nil, lockedError(path)
+		}
+		return nil, err
+	}
+	if err = writePID(f); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+
+	// the file itself stays in place: removing it would let a process which already opened it
+	// lock an unlinked inode, a leftover file is harmless as the kernel drops locks of dead processes
+	return func() error {
+		_ = f.Truncate(0)
+		if err := syscall.Flock(int(f.Fd()), syscall.LOCK_UN); err != nil {
+			_ = f.Close()
+			return err
+		}
+		return f.Close()
+	}, nil
+}
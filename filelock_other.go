@@ -0,0 +1,60 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd)
+
+package tool
+
+import (
+	"errors"
+	"os"
+	"time"
+)
+
+// lockFile Falls back to exclusively created lock file, holder refreshes its mtime as a heartbeat,
+// so the file of a crashed process is considered stale after StaleLockTimeout
+func lockFile(path string) (unlock func() error, err error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_RDWR, 0o644)
+	if errors.Is(err, os.ErrExist) {
+		fi, statErr := os.Stat(path)
+		if statErr != nil || time.Since(fi.ModTime()) < StaleLockTimeout {
+			return nil, lockedError(path)
+		}
+		tooloLog.LogAt(LevelWarn, "removing stale lock", path)
+		if err = os.Remove(path); err != nil {
+			return nil, err
+		}
+		f, err = os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_RDWR, 0o644)
+		if errors.Is(err, os.ErrExist) {
+			return nil, lockedError(path)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = writePID(f); err != nil {
+		_ = f.Close()
+		_ = os.Remove(path)
+		return nil, err
+	}
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(StaleLockTimeout / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case now := <-ticker.C:
+				_ = os.Chtimes(path, now, now)
+			}
+		}
+	}()
+
+	return func() error {
+		close(done)
+		closeErr := f.Close()
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+		return closeErr
+	}, nil
+}
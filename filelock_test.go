@@ -0,0 +1,35 @@
+package tool
+
+import (
+	"errors"
+	"path/filepath"
+)
+
+func (s *ToolTestSuite) TestWithFileLock() {
+	path := filepath.Join(s.T().TempDir(), "job.lock")
+
+	s.Run("exclusive", func() {
+		called := false
+		s.NoError(WithFileLock(path, func() error {
+			called = true
+			s.ErrorIs(WithFileLock(path, func() error {
+				s.Fail("must not run while locked")
+				return nil
+			}), ErrLocked)
+			return nil
+		}))
+		s.True(called)
+	})
+
+	s.Run("error passthrough", func() {
+		errExpected := errors.New("job failed")
+		s.ErrorIs(WithFileLock(path, func() error { return errExpected }), errExpected)
+	})
+
+	s.Run("released after panic", func() {
+		s.Panics(func() {
+			_ = WithFileLock(path, func() error { panic("boom") })
+		})
+		s.NoError(WithFileLock(path, func() error { return nil }))
+	})
+}
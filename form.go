@@ -0,0 +1,154 @@
+package tool
+
+import (
+	"bytes"
+	"encoding"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/url"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// FormTag Struct tag naming the form field, supports "-" and ",omitempty" like encoding/json
+const FormTag = "form"
+
+// Formify Encodes struct (by `form` tags, falling back to field names) or map into url.Values.
+// Slices produce repeated values, encoding.TextMarshaler is respected, nil pointers are skipped.
+func Formify(v any) (url.Values, error) {
+	values := url.Values{}
+	val := reflect.ValueOf(v)
+	for val.Kind() == reflect.Pointer {
+		if val.IsNil() {
+			return values, nil
+		}
+		val = val.Elem()
+	}
+
+	switch val.Kind() {
+	case reflect.Map:
+		if val.Type().Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("unsupported map key type %s", val.Type().Key())
+		}
+		iter := val.MapRange()
+		for iter.Next() {
+			if err := addFormValue(values, iter.Key().String(), iter.Value()); err != nil {
+				return nil, err
+			}
+		}
+	case reflect.Struct:
+		for i := 0; i < val.NumField(); i++ {
+			sf := val.Type().Field(i)
+			if !sf.IsExported() {
+				continue
+			}
+			name, opts, _ := strings.Cut(sf.Tag.Get(FormTag), ",")
+			if name == "-" {
+				continue
+			}
+			name = NonZero(name, sf.Name)
+			if opts == "omitempty" && val.Field(i).IsZero() {
+				continue
+			}
+			if err := addFormValue(values, name, val.Field(i)); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unsupported kind %s, struct or map expected", val.Kind())
+	}
+	return values, nil
+}
+
+// MultipartBody Returns multipart/form-data body with the fields and files, and its Content-Type header value.
+// Field values are formatted like Formify does, file names are taken from readers having Name() method (e.g. *os.File),
+// falling back to the field name.
+func MultipartBody(fields map[string]any, files map[string]io.Reader) (body *bytes.Buffer, contentType string, err error) {
+	body = &bytes.Buffer{}
+	w := multipart.NewWriter(body)
+
+	values := url.Values{}
+	for name, v := range fields {
+		if err = addFormValue(values, name, reflect.ValueOf(v)); err != nil {
+			return nil, "", err
+		}
+	}
+	for _, name := range SortedKeys(values) {
+		for _, value := range values[name] {
+			if err = w.WriteField(name, value); err != nil {
+				return nil, "", err
+			}
+		}
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fileName := name
+		if named, ok := files[name].(interface{ Name() string }); ok {
+			fileName = filepath.Base(named.Name())
+		}
+		part, err := w.CreateFormFile(name, fileName)
+		if err != nil {
+			return nil, "", err
+		}
+		if _, err = io.Copy(part, files[name]); err != nil {
+			return nil, "", fmt.Errorf("file %s: %w", name, err)
+		}
+	}
+
+	if err = w.Close(); err != nil {
+		return nil, "", err
+	}
+	return body, w.FormDataContentType(), nil
+}
+
+func addFormValue(values url.Values, name string, val reflect.Value) error {
+	for val.Kind() == reflect.Pointer || val.Kind() == reflect.Interface {
+		if val.IsNil() {
+			return nil
+		}
+		if _, ok := val.Interface().(encoding.TextMarshaler); ok {
+			break
+		}
+		val = val.Elem()
+	}
+	if !val.IsValid() {
+		return nil
+	}
+
+	if val.CanInterface() {
+		if m, ok := val.Interface().(encoding.TextMarshaler); ok {
+			text, err := m.MarshalText()
+			if err != nil {
+				return fmt.Errorf("field %s: %w", name, err)
+			}
+			values.Add(name, string(text))
+			return nil
+		}
+	}
+
+	switch val.Kind() {
+	case reflect.Slice, reflect.Array:
+		if val.Kind() == reflect.Slice && val.Type().Elem().Kind() == reflect.Uint8 {
+			values.Add(name, string(val.Bytes()))
+			return nil
+		}
+		for i := 0; i < val.Len(); i++ {
+			if err := addFormValue(values, name, val.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Struct, reflect.Map, reflect.Func, reflect.Chan:
+		return fmt.Errorf("field %s: unsupported kind %s", name, val.Kind())
+	default:
+		values.Add(name, fmt.Sprint(val.Interface()))
+	}
+	return nil
+}
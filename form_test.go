@@ -0,0 +1,86 @@
+package tool
+
+import (
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+func (s *ToolTestSuite) TestFormify() {
+	type form struct {
+		Name    string    `form:"name"`
+		Tags    []string  `form:"tag"`
+		Age     *int      `form:"age"`
+		Note    string    `form:"note,omitempty"`
+		Skipped string    `form:"-"`
+		At      time.Time `form:"at"`
+		Plain   bool
+		hidden  string
+	}
+
+	s.Run("struct", func() {
+		values, err := Formify(&form{
+			Name:    "tool",
+			Tags:    []string{"a", "b"},
+			Skipped: "x",
+			At:      time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+			hidden:  "y",
+		})
+		s.NoError(err)
+		s.Equal(url.Values{
+			"name":  {"tool"},
+			"tag":   {"a", "b"},
+			"at":    {"2024-01-02T03:04:05Z"},
+			"Plain": {"false"},
+		}, values)
+	})
+
+	s.Run("map", func() {
+		values, err := Formify(map[string]any{"n": 1, "list": []int{1, 2}, "nil": nil})
+		s.NoError(err)
+		s.Equal(url.Values{"n": {"1"}, "list": {"1", "2"}}, values)
+	})
+
+	s.Run("unsupported", func() {
+		_, err := Formify(42)
+		s.Error(err)
+		_, err = Formify(map[int]string{1: "a"})
+		s.Error(err)
+		_, err = Formify(struct{ Nested struct{ A int } }{})
+		s.ErrorContains(err, "field Nested")
+	})
+}
+
+func (s *ToolTestSuite) TestMultipartBody() {
+	path := filepath.Join(s.T().TempDir(), "report.txt")
+	s.Require().NoError(os.WriteFile(path, []byte("file content"), 0o600))
+	f, err := os.Open(path)
+	s.Require().NoError(err)
+	defer func() { _ = f.Close() }()
+
+	body, contentType, err := MultipartBody(
+		map[string]any{"title": "report", "ids": []int{1, 2}},
+		map[string]io.Reader{"doc": f, "raw": strings.NewReader("raw content")},
+	)
+	s.Require().NoError(err)
+
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	s.Require().NoError(err)
+	s.Equal("multipart/form-data", mediaType)
+
+	form, err := multipart.NewReader(body, params["boundary"]).ReadForm(1 << 20)
+	s.Require().NoError(err)
+	s.Equal([]string{"report"}, form.Value["title"])
+	s.Equal([]string{"1", "2"}, form.Value["ids"])
+	s.Equal("report.txt", form.File["doc"][0].Filename)
+	s.Equal("raw", form.File["raw"][0].Filename)
+
+	raw, err := form.File["raw"][0].Open()
+	s.Require().NoError(err)
+	s.Equal("raw content", string(MustReturn(io.ReadAll(raw))))
+}
@@ -0,0 +1,212 @@
+package tool
+
+import (
+	"errors"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// GlobEx Returns OS paths matching any of the patterns and none of the excludes, sorted.
+// Besides filepath.Match syntax, patterns support "**" matching any number of directories
+// and brace alternatives, e.g. "src/**/*.{go,mod}". Excludes are matched against the resulting paths.
+func GlobEx(patterns []string, excludes []string) ([]string, error) {
+	return globEx(patterns, excludes, func(root string, fn fs.WalkDirFunc) error {
+		return filepath.WalkDir(filepath.FromSlash(root), func(p string, d fs.DirEntry, err error) error {
+			return fn(filepath.ToSlash(p), d, err)
+		})
+	}, filepath.FromSlash)
+}
+
+// GlobExFS GlobEx over fs.FS, patterns are slash-separated and relative to the fsys root
+func GlobExFS(fsys fs.FS, patterns []string, excludes []string) ([]string, error) {
+	return globEx(patterns, excludes, func(root string, fn fs.WalkDirFunc) error {
+		return fs.WalkDir(fsys, root, fn)
+	}, func(p string) string { return p })
+}
+
+func globEx(patterns, excludes []string, walk func(root string, fn fs.WalkDirFunc) error, format func(string) string) ([]string, error) {
+	var expandedExcludes [][]string
+	for _, exclude := range excludes {
+		for _, e := range expandBraces(filepath.ToSlash(exclude)) {
+			segments, err := globSegments(e)
+			if err != nil {
+				return nil, err
+			}
+			expandedExcludes = append(expandedExcludes, segments)
+		}
+	}
+	excluded := func(p string) bool {
+		for _, exclude := range expandedExcludes {
+			if matchSegments(exclude, strings.Split(p, "/")) {
+				return true
+			}
+		}
+		return false
+	}
+
+	found := map[string]struct{}{}
+	for _, pattern := range patterns {
+		for _, p := range expandBraces(filepath.ToSlash(pattern)) {
+			segments, err := globSegments(p)
+			if err != nil {
+				return nil, err
+			}
+			root, rest := splitGlobRoot(segments)
+			recursive := In("**", rest...)
+
+			err = walk(root, func(p string, d fs.DirEntry, err error) error {
+				if err != nil {
+					if errors.Is(err, fs.ErrNotExist) && p == root {
+						return nil
+					}
+					return err
+				}
+				if excluded(p) {
+					if d.IsDir() {
+						return fs.SkipDir
+					}
+					return nil
+				}
+				if p == root {
+					return nil
+				}
+				var rel string
+				switch root {
+				case ".":
+					rel = p
+				case "/":
+					rel = strings.TrimPrefix(p, "/")
+				default:
+					rel = strings.TrimPrefix(p, root+"/")
+				}
+				relSegments := strings.Split(rel, "/")
+				if matchSegments(rest, relSegments) {
+					found[format(p)] = struct{}{}
+				}
+				if d.IsDir() && !recursive && len(relSegments) >= len(rest) {
+					return fs.SkipDir
+				}
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	res := make([]string, 0, len(found))
+	for p := range found {
+		res = append(res, p)
+	}
+	sort.Strings(res)
+	return res, nil
+}
+
+// globSegments Splits slash-separated pattern and validates its segments
+func globSegments(pattern string) ([]string, error) {
+	segments := strings.Split(pattern, "/")
+	for _, segment := range segments {
+		if _, err := path.Match(segment, ""); err != nil {
+			return nil, err
+		}
+	}
+	return segments, nil
+}
+
+// splitGlobRoot Splits pattern segments into the static root directory and the rest to match
+func splitGlobRoot(segments []string) (root string, rest []string) {
+	i := 0
+	for ; i < len(segments)-1; i++ {
+		if strings.ContainsAny(segments[i], `*?[\`) {
+			break
+		}
+	}
+	root = strings.Join(segments[:i], "/")
+	switch {
+	case root == "" && i > 0:
+		root = "/" // absolute pattern
+	case root == "":
+		root = "."
+	}
+	return root, segments[i:]
+}
+
+// matchSegments Matches path segments against pattern segments, "**" matches zero or more segments
+func matchSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(name); i++ {
+				if matchSegments(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], name[0]); !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
+
+// expandBraces Expands "{a,b}" alternatives, nested braces are supported, unbalanced braces are kept as is
+func expandBraces(pattern string) []string {
+	depth, start := 0, -1
+	for i := 0; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '\\':
+			i++
+		case '{':
+			if depth == 0 {
+				start = i
+			}
+			depth++
+		case '}':
+			if depth == 0 {
+				continue
+			}
+			depth--
+			if depth > 0 {
+				continue
+			}
+			var res []string
+			for _, alt := range splitTopLevel(pattern[start+1 : i]) {
+				res = append(res, expandBraces(pattern[:start]+alt+pattern[i+1:])...)
+			}
+			return res
+		}
+	}
+	return []string{pattern}
+}
+
+// splitTopLevel Splits by commas not enclosed in braces
+func splitTopLevel(s string) []string {
+	var (
+		res   []string
+		depth int
+		last  int
+	)
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '{':
+			depth++
+		case '}':
+			depth--
+		case ',':
+			if depth == 0 {
+				res = append(res, s[last:i])
+				last = i + 1
+			}
+		}
+	}
+	return append(res, s[last:])
+}
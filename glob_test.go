@@ -0,0 +1,76 @@
+package tool
+
+import (
+	"os"
+	"path/filepath"
+	"testing/fstest"
+)
+
+func (s *ToolTestSuite) TestGlobEx() {
+	tmp := s.T().TempDir()
+	for _, name := range []string{
+		"main.go", "go.mod", "README.md",
+		"pkg/a.go", "pkg/a_test.go", "pkg/deep/b.go",
+		"vendor/x/c.go",
+	} {
+		path := filepath.Join(tmp, filepath.FromSlash(name))
+		s.Require().NoError(os.MkdirAll(filepath.Dir(path), 0o755))
+		s.Require().NoError(os.WriteFile(path, nil, 0o644))
+	}
+	abs := func(names ...string) []string {
+		res := make([]string, len(names))
+		for i, name := range names {
+			res[i] = filepath.Join(tmp, filepath.FromSlash(name))
+		}
+		return res
+	}
+	root := filepath.ToSlash(tmp)
+
+	s.Run("doublestar", func() {
+		res, err := GlobEx([]string{root + "/**/*.go"}, []string{"**/vendor", "**/*_test.go"})
+		s.NoError(err)
+		s.Equal(abs("main.go", "pkg/a.go", "pkg/deep/b.go"), res)
+	})
+
+	s.Run("braces", func() {
+		res, err := GlobEx([]string{root + "/*.{go,mod}", root + "/pkg/{a,deep/b}.go"}, nil)
+		s.NoError(err)
+		s.Equal(abs("go.mod", "main.go", "pkg/a.go", "pkg/deep/b.go"), res)
+	})
+
+	s.Run("static and missing", func() {
+		res, err := GlobEx([]string{root + "/README.md", root + "/missing/*.go"}, nil)
+		s.NoError(err)
+		s.Equal(abs("README.md"), res)
+	})
+
+	s.Run("bad pattern", func() {
+		_, err := GlobEx([]string{"[/*.go"}, nil)
+		s.Error(err)
+	})
+
+	s.Run("fs", func() {
+		fsys := fstest.MapFS{
+			"a.go":          {},
+			"dir/b.go":      {},
+			"dir/b.txt":     {},
+			"dir/sub/c.go":  {},
+			"skip/d.go":     {},
+			"dir/sub/e.txt": {},
+		}
+		res, err := GlobExFS(fsys, []string{"**/*.go", "dir/*.txt"}, []string{"skip"})
+		s.NoError(err)
+		s.Equal([]string{"a.go", "dir/b.go", "dir/b.txt", "dir/sub/c.go"}, res)
+
+		res, err = GlobExFS(fsys, []string{"dir/*"}, nil)
+		s.NoError(err)
+		s.Equal([]string{"dir/b.go", "dir/b.txt", "dir/sub"}, res)
+	})
+}
+
+func (s *ToolTestSuite) TestExpandBraces() {
+	s.Equal([]string{"a.go", "a.mod"}, expandBraces("a.{go,mod}"))
+	s.Equal([]string{"ax", "ay1", "ay2"}, expandBraces("a{x,y{1,2}}"))
+	s.Equal([]string{"a{b"}, expandBraces("a{b"))
+	s.Equal([]string{"a"}, expandBraces("a"))
+}
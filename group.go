@@ -0,0 +1,93 @@
+package tool
+
+import (
+	"context"
+	"sync"
+
+	"github.com/iamwavecut/tool/safetool"
+)
+
+// Group Runs functions in goroutines like errgroup, but under Recoverer, so a panic fails only its own function,
+// and Wait returns all the failures combined instead of the first one
+type Group struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	sem    chan struct{}
+	wg     sync.WaitGroup
+
+	errMu   sync.Mutex
+	errs    []error
+	skipped bool
+}
+
+// NewGroup Returns group running up to limit functions at once, limit<1 for unlimited, and its context derived
+// from ctx, which is cancelled on the first failure or once Wait returns
+func NewGroup(ctx context.Context, limit int) (*Group, context.Context) {
+	g := &Group{}
+	g.ctx, g.cancel = context.WithCancel(ctx)
+	if limit > 0 {
+		g.sem = make(chan struct{}, limit)
+	}
+	return g, g.ctx
+}
+
+// Go Runs f in a new goroutine, blocking while the limit is reached.
+// f is skipped if the group context is done before it could start, Wait then reports the context error once.
+func (g *Group) Go(f func() error) {
+	if g.sem != nil {
+		select {
+		case g.sem <- struct{}{}:
+		case <-g.ctx.Done():
+			g.skip()
+			return
+		}
+	}
+	if g.ctx.Err() != nil {
+		g.release()
+		g.skip()
+		return
+	}
+
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		defer g.release()
+
+		var err error
+		if panicErr := Recoverer(0, func() { err = f() }, "group"); panicErr != nil {
+			err = panicErr
+		}
+		if err != nil {
+			g.errMu.Lock()
+			g.errs = append(g.errs, err)
+			g.errMu.Unlock()
+			g.cancel()
+		}
+	}()
+}
+
+// Wait Waits for the started functions and returns their errors combined, nil if all succeeded
+func (g *Group) Wait() error {
+	g.wg.Wait()
+	g.cancel()
+
+	g.errMu.Lock()
+	defer g.errMu.Unlock()
+	return safetool.CombineErrors(g.errs...)
+}
+
+// skip Records the context error of a skipped function, once per group
+func (g *Group) skip() {
+	g.errMu.Lock()
+	defer g.errMu.Unlock()
+	if !g.skipped {
+		g.skipped = true
+		g.errs = append(g.errs, g.ctx.Err())
+	}
+}
+
+func (g *Group) release() {
+	if g.sem != nil {
+		<-g.sem
+	}
+}
@@ -0,0 +1,76 @@
+package tool
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+func (s *ToolTestSuite) TestGroup() {
+	s.Run("limit", func() {
+		var running, peak, done int32
+		g, _ := NewGroup(context.Background(), 2)
+		for i := 0; i < 8; i++ {
+			g.Go(func() error {
+				n := atomic.AddInt32(&running, 1)
+				for {
+					old := atomic.LoadInt32(&peak)
+					if n <= old || atomic.CompareAndSwapInt32(&peak, old, n) {
+						break
+					}
+				}
+				time.Sleep(5 * time.Millisecond)
+				atomic.AddInt32(&running, -1)
+				atomic.AddInt32(&done, 1)
+				return nil
+			})
+		}
+		s.NoError(g.Wait())
+		s.Equal(int32(8), done)
+		s.LessOrEqual(peak, int32(2))
+	})
+
+	s.Run("all failures and panics", func() {
+		errA, errB := errors.New("a"), errors.New("b")
+		g, ctx := NewGroup(context.Background(), 0)
+		start := make(chan struct{})
+		g.Go(func() error { <-start; return errA })
+		g.Go(func() error { <-start; return errB })
+		g.Go(func() error { <-start; panic("boom") })
+		close(start)
+
+		err := g.Wait()
+		s.ErrorIs(err, errA)
+		s.ErrorIs(err, errB)
+		s.ErrorContains(err, "boom")
+		s.ErrorIs(ctx.Err(), context.Canceled)
+	})
+
+	s.Run("cancellation", func() {
+		g, ctx := NewGroup(context.Background(), 1)
+		errBoom := errors.New("boom")
+		g.Go(func() error { return errBoom })
+		<-ctx.Done()
+
+		var ran atomic.Bool
+		g.Go(func() error { ran.Store(true); return nil })
+		err := g.Wait()
+		s.ErrorIs(err, errBoom)
+		s.ErrorIs(err, context.Canceled)
+		s.False(ran.Load())
+	})
+
+	s.Run("cancelled parent", func() {
+		parent, cancel := context.WithCancel(context.Background())
+		cancel()
+		for _, limit := range []int{0, 1} {
+			g, _ := NewGroup(parent, limit)
+			var ran atomic.Bool
+			g.Go(func() error { ran.Store(true); return nil })
+			g.Go(func() error { ran.Store(true); return nil })
+			s.Equal(context.Canceled, g.Wait())
+			s.False(ran.Load())
+		}
+	})
+}
@@ -0,0 +1,75 @@
+package tool
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// MaxHTTPErrorBody Maximum number of body bytes kept in HTTPError
+const MaxHTTPErrorBody = 64 << 10
+
+// HTTPErrorHeaders Response headers kept in HTTPError
+var HTTPErrorHeaders = []string{"Content-Type", "Retry-After", "X-Request-Id", "WWW-Authenticate"}
+
+// HTTPError Non-2xx response captured by DecodeResponse
+type HTTPError struct {
+	StatusCode int         `json:"statusCode"`
+	Status     string      `json:"status"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       Varchar     `json:"body,omitempty"`
+}
+
+// Error Implements error
+func (e *HTTPError) Error() string {
+	if len(e.Body) == 0 {
+		return fmt.Sprintf("http status %s", e.Status)
+	}
+	return fmt.Sprintf("http status %s: %s", e.Status, e.Body)
+}
+
+// DecodeResponse Objectifies 2xx response body into T, non-2xx responses are returned as *HTTPError.
+// Empty 2xx body results in zero T. Body is always closed.
+func DecodeResponse[T any](resp *http.Response) (T, error) {
+	var res T
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return res, newHTTPError(resp)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return res, err
+	}
+	if len(body) == 0 {
+		return res, nil
+	}
+	if err = json.Unmarshal(body, &res); err != nil {
+		return res, fmt.Errorf("decoding response: %w", err)
+	}
+	return res, nil
+}
+
+// newHTTPError Captures status, selected headers and the beginning of the body of non-2xx response
+func newHTTPError(resp *http.Response) error {
+	body, err := io.ReadAll(io.LimitReader(resp.Body, MaxHTTPErrorBody))
+	httpErr := &HTTPError{
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Body:       Varchar(body),
+	}
+	for _, key := range HTTPErrorHeaders {
+		if values := resp.Header.Values(key); len(values) > 0 {
+			if httpErr.Header == nil {
+				httpErr.Header = http.Header{}
+			}
+			httpErr.Header[http.CanonicalHeaderKey(key)] = values
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("%w (reading body: %s)", httpErr, err)
+	}
+	return httpErr
+}
@@ -0,0 +1,60 @@
+package tool
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+)
+
+func (s *ToolTestSuite) TestDecodeResponse() {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ok":
+			_, _ = w.Write([]byte(`{"name":"tool"}`))
+		case "/empty":
+			w.WriteHeader(http.StatusNoContent)
+		case "/broken":
+			_, _ = w.Write([]byte(`{`))
+		default:
+			w.Header().Set("Retry-After", "5")
+			w.Header().Set("X-Internal", "secret")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`slow down`))
+		}
+	}))
+	defer srv.Close()
+
+	type payload struct {
+		Name string `json:"name"`
+	}
+	get := func(path string) *http.Response {
+		resp, err := http.Get(srv.URL + path)
+		s.Require().NoError(err)
+		return resp
+	}
+
+	s.Run("ok", func() {
+		res, err := DecodeResponse[payload](get("/ok"))
+		s.NoError(err)
+		s.Equal("tool", res.Name)
+	})
+	s.Run("empty", func() {
+		res, err := DecodeResponse[*payload](get("/empty"))
+		s.NoError(err)
+		s.Nil(res)
+	})
+	s.Run("broken", func() {
+		_, err := DecodeResponse[payload](get("/broken"))
+		s.ErrorContains(err, "decoding response")
+	})
+	s.Run("error", func() {
+		_, err := DecodeResponse[payload](get("/limited"))
+		var httpErr *HTTPError
+		s.Require().True(errors.As(err, &httpErr))
+		s.Equal(http.StatusTooManyRequests, httpErr.StatusCode)
+		s.Equal("slow down", httpErr.Body.String())
+		s.Equal("5", httpErr.Header.Get("Retry-After"))
+		s.Empty(httpErr.Header.Get("X-Internal"))
+		s.EqualError(err, "http status 429 Too Many Requests: slow down")
+	})
+}
@@ -0,0 +1,273 @@
+package tool
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/format"
+	"go/token"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// InferInitialisms Words rendered upper-cased in field names generated by InferStruct
+var InferInitialisms = []string{"ID", "URL", "URI", "API", "HTTP", "HTTPS", "JSON", "HTML", "IP", "UUID", "SQL"}
+
+// InferStruct Returns gofmt-ed Go source of types matching the JSON sample, the root type is named typeName.
+// Nested objects become separate types named after their path, array elements are merged into a singular-named
+// type, e.g. Items []OrderItem. Fields that are null in some samples become pointers, fields missing
+// in some array elements get omitempty. Numbers without fraction are int64, conflicting or unknown types are any.
+func InferStruct(sample Varchar, typeName string) (string, error) {
+	if !token.IsIdentifier(typeName) {
+		return "", fmt.Errorf("infer struct: invalid type name %q", typeName)
+	}
+	dec := json.NewDecoder(strings.NewReader(sample.String()))
+	dec.UseNumber()
+	shape, err := inferShape(dec)
+	if err != nil {
+		return "", fmt.Errorf("infer struct: %w", err)
+	}
+	if shape.kind != shapeObject && shape.kind != shapeArray {
+		return "", fmt.Errorf("infer struct: sample must be an object or array, got %s", shape.kind)
+	}
+
+	r := &structRenderer{names: map[string]bool{}}
+	if shape.kind == shapeArray {
+		r.names[typeName] = true
+		r.types = append(r.types, "")
+		r.types[0] = fmt.Sprintf("type %s %s", typeName, r.goType(shape, typeName)) // element types follow
+	} else {
+		r.renderStruct(typeName, shape)
+	}
+
+	src, err := format.Source([]byte(strings.Join(r.types, "\n\n")))
+	if err != nil {
+		return "", fmt.Errorf("infer struct: %w", err)
+	}
+	return string(src) + "\n", nil
+}
+
+type shapeKind int
+
+const (
+	shapeNull shapeKind = iota
+	shapeBool
+	shapeInt
+	shapeFloat
+	shapeString
+	shapeObject
+	shapeArray
+	shapeMixed
+)
+
+func (k shapeKind) String() string {
+	return [...]string{"null", "bool", "integer", "float", "string", "object", "array", "mixed"}[k]
+}
+
+// jsonShape Type information collected from one or more sample values
+type jsonShape struct {
+	kind     shapeKind
+	nullable bool
+	// objects Number of merged objects, compared with field counts to detect optional fields
+	objects int
+	keys    []string
+	fields  map[string]*jsonField
+	elem    *jsonShape
+}
+
+type jsonField struct {
+	shape *jsonShape
+	count int
+}
+
+func inferShape(dec *json.Decoder) (*jsonShape, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch t := tok.(type) {
+	case json.Delim:
+		if t == '{' {
+			shape := &jsonShape{kind: shapeObject, objects: 1, fields: map[string]*jsonField{}}
+			for dec.More() {
+				keyTok, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				value, err := inferShape(dec)
+				if err != nil {
+					return nil, err
+				}
+				shape.addField(keyTok.(string), value, 1)
+			}
+			_, err = dec.Token()
+			return shape, err
+		}
+		shape := &jsonShape{kind: shapeArray}
+		for dec.More() {
+			value, err := inferShape(dec)
+			if err != nil {
+				return nil, err
+			}
+			shape.elem = mergeShapes(shape.elem, value)
+		}
+		_, err = dec.Token()
+		return shape, err
+	case json.Number:
+		if _, err := strconv.ParseInt(t.String(), 10, 64); err == nil {
+			return &jsonShape{kind: shapeInt}, nil
+		}
+		return &jsonShape{kind: shapeFloat}, nil
+	case string:
+		return &jsonShape{kind: shapeString}, nil
+	case bool:
+		return &jsonShape{kind: shapeBool}, nil
+	case nil:
+		return &jsonShape{kind: shapeNull, nullable: true}, nil
+	}
+	return nil, errors.New("unexpected token")
+}
+
+func (s *jsonShape) addField(key string, value *jsonShape, count int) {
+	field, ok := s.fields[key]
+	if !ok {
+		s.keys = append(s.keys, key)
+		s.fields[key] = &jsonField{shape: value, count: count}
+		return
+	}
+	field.shape = mergeShapes(field.shape, value)
+	field.count += count
+}
+
+// mergeShapes Returns shape matching values of both a and b
+func mergeShapes(a, b *jsonShape) *jsonShape {
+	switch {
+	case a == nil:
+		return b
+	case b == nil:
+		return a
+	case a.kind == shapeNull:
+		merged := *b
+		merged.nullable = true
+		return &merged
+	case b.kind == shapeNull:
+		merged := *a
+		merged.nullable = true
+		return &merged
+	}
+
+	merged := &jsonShape{kind: a.kind, nullable: a.nullable || b.nullable}
+	switch {
+	case a.kind == b.kind && a.kind == shapeObject:
+		merged.objects = a.objects + b.objects
+		merged.fields = map[string]*jsonField{}
+		for _, src := range []*jsonShape{a, b} {
+			for _, key := range src.keys {
+				merged.addField(key, src.fields[key].shape, src.fields[key].count)
+			}
+		}
+	case a.kind == b.kind && a.kind == shapeArray:
+		merged.elem = mergeShapes(a.elem, b.elem)
+	case a.kind == b.kind:
+	case (a.kind == shapeInt || a.kind == shapeFloat) && (b.kind == shapeInt || b.kind == shapeFloat):
+		merged.kind = shapeFloat
+	default:
+		merged.kind = shapeMixed
+	}
+	return merged
+}
+
+type structRenderer struct {
+	types []string
+	names map[string]bool
+}
+
+// renderStruct Adds struct type declaration, nested types follow their parent
+func (r *structRenderer) renderStruct(name string, shape *jsonShape) {
+	r.names[name] = true
+	slot := len(r.types)
+	r.types = append(r.types, "")
+
+	var buf strings.Builder
+	buf.WriteString("type " + name + " struct {\n")
+	used := map[string]bool{}
+	for _, key := range shape.keys {
+		field := shape.fields[key]
+		fieldName := uniqueName(exportedName(key), used)
+		tag := key
+		if field.count < shape.objects {
+			tag += ",omitempty"
+		}
+		buf.WriteString(fmt.Sprintf("%s %s `json:%s`\n", fieldName, r.goType(field.shape, name+fieldName), strconv.Quote(tag)))
+	}
+	buf.WriteString("}")
+	r.types[slot] = buf.String()
+}
+
+// goType Returns Go type of the shape, declaring struct types named after name when needed
+func (r *structRenderer) goType(shape *jsonShape, name string) string {
+	if shape == nil {
+		return "any"
+	}
+	var typ string
+	switch shape.kind {
+	case shapeNull, shapeMixed:
+		return "any"
+	case shapeArray:
+		return "[]" + r.goType(shape.elem, singular(name))
+	case shapeBool:
+		typ = "bool"
+	case shapeInt:
+		typ = "int64"
+	case shapeFloat:
+		typ = "float64"
+	case shapeString:
+		typ = "string"
+	case shapeObject:
+		typ = uniqueName(name, r.names)
+		r.renderStruct(typ, shape)
+	}
+	if shape.nullable {
+		return "*" + typ
+	}
+	return typ
+}
+
+// singular Returns name of array element type, e.g. "Items" to "Item"
+func singular(name string) string {
+	if len(name) > 1 && strings.HasSuffix(name, "s") && !strings.HasSuffix(name, "ss") {
+		return strings.TrimSuffix(name, "s")
+	}
+	return name + "Item"
+}
+
+// exportedName Converts JSON key to exported Go identifier, e.g. "user_id" to "UserID"
+func exportedName(key string) string {
+	words := strings.FieldsFunc(key, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) })
+	var buf strings.Builder
+	for _, word := range words {
+		if upper := strings.ToUpper(word); In(upper, InferInitialisms...) {
+			buf.WriteString(upper)
+			continue
+		}
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		buf.WriteString(string(runes))
+	}
+	name := buf.String()
+	if name == "" || !unicode.IsLetter([]rune(name)[0]) {
+		name = "Field" + name
+	}
+	return name
+}
+
+// uniqueName Returns name, suffixed with a number if it is already used, and marks it used
+func uniqueName(name string, used map[string]bool) string {
+	unique := name
+	for i := 2; used[unique]; i++ {
+		unique = name + strconv.Itoa(i)
+	}
+	used[unique] = true
+	return unique
+}
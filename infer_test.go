@@ -0,0 +1,80 @@
+package tool
+
+func (s *ToolTestSuite) TestInferStruct() {
+	s.Run("object", func() {
+		src, err := InferStruct(`{
+			"id": 1,
+			"user_name": "a",
+			"score": 1.5,
+			"active": true,
+			"deleted_at": null,
+			"avatar_url": "x",
+			"owner": {"id": 2, "email": "e"},
+			"tags": ["a"],
+			"empty": [],
+			"items": [
+				{"sku": "a", "qty": 1, "note": null},
+				{"sku": "b", "qty": 2.5, "note": "n", "gift": true}
+			],
+			"mixed": [1, "a"]
+		}`, "Order")
+		s.NoError(err)
+		s.Equal(`type Order struct {
+	ID        int64       `+"`json:\"id\"`"+`
+	UserName  string      `+"`json:\"user_name\"`"+`
+	Score     float64     `+"`json:\"score\"`"+`
+	Active    bool        `+"`json:\"active\"`"+`
+	DeletedAt any         `+"`json:\"deleted_at\"`"+`
+	AvatarURL string      `+"`json:\"avatar_url\"`"+`
+	Owner     OrderOwner  `+"`json:\"owner\"`"+`
+	Tags      []string    `+"`json:\"tags\"`"+`
+	Empty     []any       `+"`json:\"empty\"`"+`
+	Items     []OrderItem `+"`json:\"items\"`"+`
+	Mixed     []any       `+"`json:\"mixed\"`"+`
+}
+
+type OrderOwner struct {
+	ID    int64  `+"`json:\"id\"`"+`
+	Email string `+"`json:\"email\"`"+`
+}
+
+type OrderItem struct {
+	Sku  string  `+"`json:\"sku\"`"+`
+	Qty  float64 `+"`json:\"qty\"`"+`
+	Note *string `+"`json:\"note\"`"+`
+	Gift bool    `+"`json:\"gift,omitempty\"`"+`
+}
+`, src)
+	})
+
+	s.Run("array and name clashes", func() {
+		src, err := InferStruct(`[{"a-b": 1, "a_b": 2, "1st": {"x": null}, "parent": null}, {"parent": {"n": 1}}]`, "Rows")
+		s.NoError(err)
+		s.Equal(`type Rows []Row
+
+type Row struct {
+	AB       int64       `+"`json:\"a-b,omitempty\"`"+`
+	AB2      int64       `+"`json:\"a_b,omitempty\"`"+`
+	Field1st RowField1st `+"`json:\"1st,omitempty\"`"+`
+	Parent   *RowParent  `+"`json:\"parent\"`"+`
+}
+
+type RowField1st struct {
+	X any `+"`json:\"x\"`"+`
+}
+
+type RowParent struct {
+	N int64 `+"`json:\"n\"`"+`
+}
+`, src)
+	})
+
+	s.Run("errors", func() {
+		_, err := InferStruct(`{"a":`, "T")
+		s.Error(err)
+		_, err = InferStruct(`1`, "T")
+		s.EqualError(err, "infer struct: sample must be an object or array, got integer")
+		_, err = InferStruct(`{}`, "not valid")
+		s.Error(err)
+	})
+}
@@ -0,0 +1,168 @@
+package tool
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ErrUnknownEnvelope Returned by ObjectifyEnvelope for a missing or unregistered type discriminator
+var ErrUnknownEnvelope = errors.New("unknown envelope type")
+
+// ObjectifyEnvelope Decodes polymorphic JSON object into the target built by registry constructor
+// chosen by typeField value, e.g. {"type":"created",...} with registry{"created": func() any { return &Created{} }}.
+// Returns the populated target.
+func ObjectifyEnvelope(in Varchar, typeField string, registry map[string]func() any) (any, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(in.Bytes(), &fields); err != nil {
+		return nil, err
+	}
+	rawType, ok := fields[typeField]
+	if !ok {
+		return nil, fmt.Errorf("%w: missing %q field", ErrUnknownEnvelope, typeField)
+	}
+	var kind string
+	if err := json.Unmarshal(rawType, &kind); err != nil {
+		return nil, fmt.Errorf("%w: %q field is not a string", ErrUnknownEnvelope, typeField)
+	}
+	newTarget, ok := registry[kind]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownEnvelope, kind)
+	}
+
+	target := newTarget()
+	if err := json.Unmarshal(in.Bytes(), target); err != nil {
+		return nil, fmt.Errorf("envelope %q: %w", kind, err)
+	}
+	return target, nil
+}
+
+// truncationMarkerKey Object key holding the count of dropped keys in JsonifyMax output
+const truncationMarkerKey = "…"
+
+// JsonifyMax Returns Varchar implementation of the serialized value, at most maxBytes long.
+// Oversized values are shrunk structurally: array tails, object keys and string tails are dropped
+// and replaced with "…(+N)" markers, so the result is always valid JSON. Returns empty on error
+// or when even the marker doesn't fit.
+func JsonifyMax(v any, maxBytes int) Varchar {
+	full := Jsonify(v)
+	if len(full) <= maxBytes {
+		return full
+	}
+
+	var generic any
+	dec := json.NewDecoder(strings.NewReader(full.String()))
+	dec.UseNumber()
+	if Try(dec.Decode(&generic), true) {
+		return ""
+	}
+
+	strLimit, itemLimit := maxBytes, maxBytes
+	for strLimit > 0 || itemLimit > 0 {
+		strLimit, itemLimit = strLimit/2, itemLimit/2
+		if res := Jsonify(truncateJSON(generic, strLimit, itemLimit)); len(res) <= maxBytes {
+			return res
+		}
+	}
+
+	if marker := Jsonify(truncationMarker(len(full))); len(marker) <= maxBytes {
+		return marker
+	}
+	return ""
+}
+
+// truncateJSON Returns a copy of decoded JSON value with strings and collections cut to the limits
+func truncateJSON(v any, strLimit, itemLimit int) any {
+	switch val := v.(type) {
+	case string:
+		runes := []rune(val)
+		if len(runes) <= strLimit {
+			return val
+		}
+		return string(runes[:strLimit]) + truncationMarker(len(runes)-strLimit)
+	case []any:
+		res := make([]any, 0, itemLimit+1)
+		for i, item := range val {
+			if i == itemLimit {
+				res = append(res, truncationMarker(len(val)-itemLimit))
+				break
+			}
+			res = append(res, truncateJSON(item, strLimit, itemLimit))
+		}
+		return res
+	case map[string]any:
+		keys := make([]string, 0, len(val))
+		for key := range val {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		res := make(map[string]any, itemLimit+1)
+		for i, key := range keys {
+			if i == itemLimit {
+				res[truncationMarkerKey] = truncationMarker(len(keys) - itemLimit)
+				break
+			}
+			res[key] = truncateJSON(val[key], strLimit, itemLimit)
+		}
+		return res
+	}
+	return v
+}
+
+func truncationMarker(dropped int) string {
+	return fmt.Sprintf("…(+%d)", dropped)
+}
+
+// JSONEqual Reports whether a and b hold the same JSON value, ignoring whitespace and object key order.
+// Numbers are compared by value, so 1, 1.0 and 1e0 are equal.
+func JSONEqual(a, b Varchar) (bool, error) {
+	va, err := decodeJSONValue(a)
+	if err != nil {
+		return false, fmt.Errorf("first value: %w", err)
+	}
+	vb, err := decodeJSONValue(b)
+	if err != nil {
+		return false, fmt.Errorf("second value: %w", err)
+	}
+	return reflect.DeepEqual(va, vb), nil
+}
+
+// decodeJSONValue Decodes single JSON value with numbers normalized to exact rational strings
+func decodeJSONValue(in Varchar) (any, error) {
+	dec := json.NewDecoder(strings.NewReader(in.String()))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return nil, errors.New("unexpected data after JSON value")
+	}
+	return normalizeJSONNumbers(v), nil
+}
+
+// jsonRat Normalized JSON number, distinct from strings
+type jsonRat string
+
+func normalizeJSONNumbers(v any) any {
+	switch v := v.(type) {
+	case json.Number:
+		if r, ok := new(big.Rat).SetString(v.String()); ok {
+			return jsonRat(r.RatString())
+		}
+	case map[string]any:
+		for k, e := range v {
+			v[k] = normalizeJSONNumbers(e)
+		}
+	case []any:
+		for i, e := range v {
+			v[i] = normalizeJSONNumbers(e)
+		}
+	}
+	return v
+}
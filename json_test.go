@@ -0,0 +1,118 @@
+package tool
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+func (s *ToolTestSuite) TestJsonifyMax() {
+	s.Run("fits", func() {
+		s.Equal(`{"a":1}`, JsonifyMax(map[string]int{"a": 1}, 100).String())
+	})
+
+	s.Run("array tail", func() {
+		items := make([]int, 1000)
+		res := JsonifyMax(items, 100)
+		s.LessOrEqual(len(res), 100)
+		s.True(json.Valid(res.Bytes()))
+		s.Contains(res.String(), "…(+")
+		s.True(strings.HasPrefix(res.String(), "[0,0,"))
+	})
+
+	s.Run("long string", func() {
+		res := JsonifyMax(map[string]string{"msg": strings.Repeat("x", 1000)}, 80)
+		s.LessOrEqual(len(res), 80)
+		s.True(json.Valid(res.Bytes()))
+		var out map[string]string
+		s.True(Objectify(res, &out))
+		s.True(strings.HasPrefix(out["msg"], "xxx"))
+		s.Contains(out["msg"], "…(+")
+	})
+
+	s.Run("object keys", func() {
+		in := map[string]int{}
+		for _, key := range strings.Split("abcdefghijklmnopqrstuvwxyz", "") {
+			in[key] = 1
+		}
+		res := JsonifyMax(in, 50)
+		s.LessOrEqual(len(res), 50)
+		var out map[string]any
+		s.True(Objectify(res, &out))
+		s.Contains(out, "a")
+		s.Contains(out, truncationMarkerKey)
+	})
+
+	s.Run("too small", func() {
+		s.Equal(`"…(+3999)"`, JsonifyMax(strings.Repeat("x", 3999), 12).String())
+		s.Empty(JsonifyMax(strings.Repeat("x", 100), 3))
+	})
+
+	s.Run("invalid", func() {
+		s.Empty(JsonifyMax(func() {}, 10))
+	})
+}
+
+func (s *ToolTestSuite) TestObjectifyEnvelope() {
+	type created struct {
+		Type string `json:"type"`
+		ID   int    `json:"id"`
+	}
+	type deleted struct {
+		ID     int    `json:"id"`
+		Reason string `json:"reason"`
+	}
+	registry := map[string]func() any{
+		"created": func() any { return &created{} },
+		"deleted": func() any { return &deleted{} },
+	}
+
+	s.Run("dispatch", func() {
+		v, err := ObjectifyEnvelope(`{"type":"created","id":1}`, "type", registry)
+		s.NoError(err)
+		s.Equal(&created{Type: "created", ID: 1}, v)
+
+		v, err = ObjectifyEnvelope(`{"id":2,"reason":"gone","type":"deleted"}`, "type", registry)
+		s.NoError(err)
+		s.Equal(&deleted{ID: 2, Reason: "gone"}, v)
+	})
+
+	s.Run("errors", func() {
+		_, err := ObjectifyEnvelope(`{"type":"updated"}`, "type", registry)
+		s.ErrorIs(err, ErrUnknownEnvelope)
+		_, err = ObjectifyEnvelope(`{"id":1}`, "type", registry)
+		s.ErrorIs(err, ErrUnknownEnvelope)
+		_, err = ObjectifyEnvelope(`{"type":1}`, "type", registry)
+		s.ErrorIs(err, ErrUnknownEnvelope)
+		_, err = ObjectifyEnvelope(`[]`, "type", registry)
+		s.Error(err)
+		_, err = ObjectifyEnvelope(`{"type":"created","id":"x"}`, "type", registry)
+		s.ErrorContains(err, `envelope "created": json: cannot unmarshal string`)
+	})
+}
+
+func (s *ToolTestSuite) TestJSONEqual() {
+	for a, b := range map[Varchar]Varchar{
+		`{"a":1,"b":[1,2,{"c":null}]}`: "{\n  \"b\": [1, 2.0, {\"c\": null}],\n  \"a\": 1e0\n}",
+		`12345678901234567890`:         `12345678901234567890.0`,
+		`"x"`:                          ` "x" `,
+	} {
+		eq, err := JSONEqual(a, b)
+		s.NoError(err)
+		s.True(eq, a)
+	}
+	for a, b := range map[Varchar]Varchar{
+		`{"a":1}`:              `{"a":1,"b":null}`,
+		`[1,2]`:                `[2,1]`,
+		`12345678901234567890`: `12345678901234567891`,
+		`"1"`:                  `1`,
+	} {
+		eq, err := JSONEqual(a, b)
+		s.NoError(err)
+		s.False(eq, a)
+	}
+
+	_, err := JSONEqual(`{`, `{}`)
+	s.ErrorContains(err, "first value")
+	_, err = JSONEqual(`{}`, `{} {}`)
+	s.ErrorContains(err, "second value")
+}
@@ -0,0 +1,200 @@
+package tool
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// JWTAlg Supported JWT signing algorithm
+type JWTAlg string
+
+const (
+	// HS256 HMAC-SHA256 with a shared secret key
+	HS256 JWTAlg = "HS256"
+	// RS256 RSASSA-PKCS1-v1_5 SHA-256, signed with a PEM private key and verified with a PEM public key
+	RS256 JWTAlg = "RS256"
+)
+
+var (
+	// ErrJWTMalformed Returned for tokens that can't be decoded
+	ErrJWTMalformed = errors.New("malformed token")
+	// ErrJWTExpired Returned for tokens past their exp claim
+	ErrJWTExpired = errors.New("token expired")
+	// ErrJWTNotYetValid Returned for tokens before their nbf claim
+	ErrJWTNotYetValid = errors.New("token not valid yet")
+
+	// JWTLeeway Clock skew tolerated when validating exp and nbf claims
+	JWTLeeway = time.Minute
+
+	jwtEncoding = base64.RawURLEncoding
+)
+
+type jwtHeader struct {
+	Alg JWTAlg `json:"alg"`
+	Typ string `json:"typ"`
+}
+
+// SignJWT Returns compact JWT with claims signed by key using alg
+func SignJWT(claims any, key []byte, alg JWTAlg) (Varchar, error) {
+	header, err := json.Marshal(jwtHeader{Alg: alg, Typ: "JWT"})
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	signingInput := jwtEncoding.EncodeToString(header) + "." + jwtEncoding.EncodeToString(payload)
+
+	var sig []byte
+	switch alg {
+	case HS256:
+		sig = hmacSHA256(string(key), []byte(signingInput))
+	case RS256:
+		privateKey, err := parseRSAPrivateKey(key)
+		if err != nil {
+			return "", err
+		}
+		digest := sha256.Sum256([]byte(signingInput))
+		if sig, err = rsa.SignPKCS1v15(rand.Reader, privateKey, crypto.SHA256, digest[:]); err != nil {
+			return "", err
+		}
+	default:
+		return "", fmt.Errorf("unsupported alg %q", alg)
+	}
+	return Varchar(signingInput + "." + jwtEncoding.EncodeToString(sig)), nil
+}
+
+// ParseJWT Verifies the token signature and exp/nbf claims and decodes claims into T.
+// PEM key means RS256 public key, any other key is an HS256 secret, tokens of the other alg are rejected.
+func ParseJWT[T any, S ~string | ~[]byte](token S, key []byte) (T, error) {
+	var claims T
+	parts := strings.Split(string(token), ".")
+	if len(parts) != 3 {
+		return claims, ErrJWTMalformed
+	}
+	var header jwtHeader
+	if err := decodeJWTPart(parts[0], &header); err != nil {
+		return claims, err
+	}
+	sig, err := jwtEncoding.DecodeString(parts[2])
+	if err != nil {
+		return claims, ErrJWTMalformed
+	}
+
+	signingInput := parts[0] + "." + parts[1]
+	isPEM := bytes.HasPrefix(bytes.TrimSpace(key), []byte("-----BEGIN"))
+	switch {
+	case header.Alg == HS256 && !isPEM:
+		if !SecureEqual(sig, hmacSHA256(string(key), []byte(signingInput))) {
+			return claims, ErrInvalidSignature
+		}
+	case header.Alg == RS256 && isPEM:
+		publicKey, err := parseRSAPublicKey(key)
+		if err != nil {
+			return claims, err
+		}
+		digest := sha256.Sum256([]byte(signingInput))
+		if rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, digest[:], sig) != nil {
+			return claims, ErrInvalidSignature
+		}
+	default:
+		return claims, fmt.Errorf("%w: unexpected alg %q for the key", ErrInvalidSignature, header.Alg)
+	}
+
+	var registered struct {
+		Exp *json.Number `json:"exp"`
+		Nbf *json.Number `json:"nbf"`
+	}
+	if err = decodeJWTPart(parts[1], &registered); err != nil {
+		return claims, err
+	}
+	now := clockNow()
+	if registered.Exp != nil {
+		exp, err := registered.Exp.Float64()
+		if err != nil {
+			return claims, ErrJWTMalformed
+		}
+		if now.After(time.Unix(int64(exp), 0).Add(JWTLeeway)) {
+			return claims, ErrJWTExpired
+		}
+	}
+	if registered.Nbf != nil {
+		nbf, err := registered.Nbf.Float64()
+		if err != nil {
+			return claims, ErrJWTMalformed
+		}
+		if now.Before(time.Unix(int64(nbf), 0).Add(-JWTLeeway)) {
+			return claims, ErrJWTNotYetValid
+		}
+	}
+
+	return claims, decodeJWTPart(parts[1], &claims)
+}
+
+func decodeJWTPart(part string, target any) error {
+	b, err := jwtEncoding.DecodeString(part)
+	if err != nil {
+		return ErrJWTMalformed
+	}
+	if err = json.Unmarshal(b, target); err != nil {
+		return fmt.Errorf("%w: %s", ErrJWTMalformed, err)
+	}
+	return nil
+}
+
+func parseRSAPrivateKey(key []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(key)
+	if block == nil {
+		return nil, errors.New("PEM encoded RSA private key expected")
+	}
+	if privateKey, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return privateKey, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	privateKey, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("not an RSA private key")
+	}
+	return privateKey, nil
+}
+
+func parseRSAPublicKey(key []byte) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode(key)
+	if block == nil {
+		return nil, errors.New("PEM encoded RSA public key expected")
+	}
+	if block.Type == "CERTIFICATE" {
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		block.Bytes = cert.RawSubjectPublicKeyInfo
+	}
+	if publicKey, err := x509.ParsePKCS1PublicKey(block.Bytes); err == nil {
+		return publicKey, nil
+	}
+	parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	publicKey, ok := parsed.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.New("not an RSA public key")
+	}
+	return publicKey, nil
+}
@@ -0,0 +1,70 @@
+package tool
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"strings"
+	"time"
+)
+
+type testClaims struct {
+	Sub string `json:"sub"`
+	Exp int64  `json:"exp,omitempty"`
+	Nbf int64  `json:"nbf,omitempty"`
+}
+
+func (s *ToolTestSuite) TestJWT() {
+	secret := []byte("s3cr3t")
+
+	s.Run("hs256", func() {
+		token, err := SignJWT(testClaims{Sub: "user", Exp: time.Now().Add(time.Hour).Unix()}, secret, HS256)
+		s.Require().NoError(err)
+		s.Len(strings.Split(token.String(), "."), 3)
+
+		claims, err := ParseJWT[testClaims](token, secret)
+		s.NoError(err)
+		s.Equal("user", claims.Sub)
+
+		_, err = ParseJWT[testClaims](token, []byte("other"))
+		s.ErrorIs(err, ErrInvalidSignature)
+	})
+
+	s.Run("claims validation", func() {
+		expired := MustReturn(SignJWT(testClaims{Exp: time.Now().Add(-time.Hour).Unix()}, secret, HS256))
+		_, err := ParseJWT[testClaims](expired, secret)
+		s.ErrorIs(err, ErrJWTExpired)
+
+		early := MustReturn(SignJWT(testClaims{Nbf: time.Now().Add(time.Hour).Unix()}, secret, HS256))
+		_, err = ParseJWT[map[string]any](early.String(), secret)
+		s.ErrorIs(err, ErrJWTNotYetValid)
+	})
+
+	s.Run("malformed", func() {
+		_, err := ParseJWT[testClaims]("a.b", secret)
+		s.ErrorIs(err, ErrJWTMalformed)
+		_, err = ParseJWT[testClaims]("!.!.!", secret)
+		s.ErrorIs(err, ErrJWTMalformed)
+		_, err = SignJWT(testClaims{}, secret, "none")
+		s.Error(err)
+	})
+
+	s.Run("rs256", func() {
+		privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		s.Require().NoError(err)
+		privatePEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(privateKey)})
+		publicPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: MustReturn(x509.MarshalPKIXPublicKey(&privateKey.PublicKey))})
+
+		token, err := SignJWT(testClaims{Sub: "service"}, privatePEM, RS256)
+		s.Require().NoError(err)
+		claims, err := ParseJWT[testClaims](token, publicPEM)
+		s.NoError(err)
+		s.Equal("service", claims.Sub)
+
+		// alg confusion: HS256 token signed with the public key must not pass as RS256 key
+		forged := MustReturn(SignJWT(testClaims{Sub: "admin"}, publicPEM, HS256))
+		_, err = ParseJWT[testClaims](forged, publicPEM)
+		s.ErrorIs(err, ErrInvalidSignature)
+	})
+}
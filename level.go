@@ -0,0 +1,116 @@
+package tool
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// LogLevel Severity of package log messages
+type LogLevel int32
+
+const (
+	// LevelDebug Diagnostics, e.g. retried errors
+	LevelDebug LogLevel = iota
+	// LevelInfo Regular output, e.g. Console
+	LevelInfo
+	// LevelWarn Recoverable problems
+	LevelWarn
+	// LevelError Errors, e.g. verbose Try and recovered panics
+	LevelError
+)
+
+// logLevel Minimal level of logged messages, LevelDebug by default
+var logLevel atomic.Int32
+
+// SetLogLevel Drops package log messages below level, LevelDebug logs everything
+func SetLogLevel(level LogLevel) {
+	logLevel.Store(int32(level))
+}
+
+// GetLogLevel Returns the level set with SetLogLevel
+func GetLogLevel() LogLevel {
+	return LogLevel(logLevel.Load())
+}
+
+// String Returns level name
+func (l LogLevel) String() string {
+	switch l {
+	case LevelDebug:
+		return "DEBUG"
+	case LevelInfo:
+		return "INFO"
+	case LevelWarn:
+		return "WARN"
+	case LevelError:
+		return "ERROR"
+	}
+	return fmt.Sprintf("LogLevel(%d)", int32(l))
+}
+
+// ParseLogLevel Returns level by its case-insensitive name, as returned by String
+func ParseLogLevel(name string) (LogLevel, error) {
+	for _, level := range []LogLevel{LevelDebug, LevelInfo, LevelWarn, LevelError} {
+		if strings.EqualFold(name, level.String()) {
+			return level, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown log level %q", name)
+}
+
+// enabled Reports whether messages of the level pass SetLogLevel filter
+func (l LogLevel) enabled() bool {
+	return int32(l) >= logLevel.Load()
+}
+
+// slogLevel Returns matching slog level
+func (l LogLevel) slogLevel() slog.Level {
+	switch l {
+	case LevelDebug:
+		return slog.LevelDebug
+	case LevelWarn:
+		return slog.LevelWarn
+	case LevelError:
+		return slog.LevelError
+	}
+	return slog.LevelInfo
+}
+
+// loggerWriter Line-buffered io.Writer logging every complete line with the package logger
+type loggerWriter struct {
+	mu    sync.Mutex
+	level LogLevel
+	buf   []byte
+}
+
+// LoggerWriter Returns io.Writer logging every written line at level through the package logger configured by SetLogger,
+// e.g. http.Server{ErrorLog: log.New(tool.LoggerWriter(tool.LevelError), "", 0)}.
+// Incomplete lines are buffered until the newline arrives, empty lines are dropped.
+func LoggerWriter(level LogLevel) io.Writer {
+	return &loggerWriter{level: level}
+}
+
+// Write Implements io.Writer
+func (w *loggerWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		if line := strings.TrimRight(string(w.buf[:i]), "\r"); line != "" {
+			tooloLog.LogAt(w.level, line)
+		}
+		w.buf = w.buf[i+1:]
+	}
+	if len(w.buf) == 0 {
+		w.buf = nil
+	}
+	return len(p), nil
+}
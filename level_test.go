@@ -0,0 +1,113 @@
+package tool
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type rusLogger struct {
+	testLogger
+	entries []string
+}
+
+type rusEntry struct {
+	l   *rusLogger
+	err error
+}
+
+func (r *rusLogger) WithError(err error) LogRus { return &rusEntry{l: r, err: err} }
+func (r *rusLogger) Errorln(a ...any)           { r.entries = append(r.entries, fmt.Sprint(a...)) }
+
+func (e *rusEntry) Println(a ...any)           { e.l.Println(a...) }
+func (e *rusEntry) Panicln(a ...any)           { e.l.Panicln(a...) }
+func (e *rusEntry) Printf(s string, a ...any)  { e.l.Printf(s, a...) }
+func (e *rusEntry) Print(a ...any)             { e.l.Print(a...) }
+func (e *rusEntry) WithError(err error) LogRus { return &rusEntry{l: e.l, err: err} }
+func (e *rusEntry) Errorln(a ...any)           { e.log("error", a...) }
+func (e *rusEntry) Debugln(a ...any)           { e.log("debug", a...) }
+func (e *rusEntry) log(level string, a ...any) {
+	e.l.entries = append(e.l.entries, level+" "+fmt.Sprint(a...)+" "+e.err.Error())
+}
+
+func (s *ToolTestSuite) TestSetLogLevel() {
+	defer SetLogLevel(LevelDebug)
+	retry := func() {
+		calls := 0
+		_ = RetryFunc(1, 0, func() error {
+			calls++
+			if calls == 1 {
+				return errors.New("flaky")
+			}
+			return nil
+		})
+	}
+
+	s.Run("debug", func() {
+		retry()
+		s.Equal("retrying after error: flaky\n", testLog.buf)
+	})
+
+	s.Run("info", func() {
+		testLog.buf = ""
+		SetLogLevel(LevelInfo)
+		retry()
+		s.Empty(testLog.buf)
+		Console("shown")
+		s.Contains(testLog.buf, "shown")
+	})
+
+	s.Run("error", func() {
+		testLog.buf = ""
+		SetLogLevel(LevelError)
+		s.Equal(LevelError, GetLogLevel())
+		Console("hidden")
+		tooloLog.LogAt(LevelWarn, "hidden")
+		s.Empty(testLog.buf)
+		Try(errors.New("shown"), true)
+		s.Equal("shown\n", testLog.buf)
+	})
+
+	s.Run("logrus", func() {
+		SetLogLevel(LevelDebug)
+		rus := &rusLogger{}
+		SetLogger(rus)
+		defer SetLogger(testLog)
+		retry()
+		tooloLog.LogErrorAt(LevelWarn, errors.New("warned"))
+		s.Equal([]string{"debug retrying after error flaky", "error  warned"}, rus.entries)
+	})
+
+	s.Run("names", func() {
+		s.Equal("WARN", LevelWarn.String())
+		s.Equal("LogLevel(9)", LogLevel(9).String())
+	})
+}
+
+func (s *ToolTestSuite) TestLoggerWriter() {
+	defer SetLogLevel(LevelDebug)
+	testLog.buf = ""
+	w := LoggerWriter(LevelWarn)
+	n, err := w.Write([]byte("first line\nsecond "))
+	s.NoError(err)
+	s.Equal(18, n)
+	s.Equal("first line\n", testLog.buf)
+
+	_, _ = fmt.Fprint(w, "part\r\n\n")
+	s.Equal("first line\nsecond part\n", testLog.buf)
+
+	testLog.buf = ""
+	SetLogLevel(LevelError)
+	_, _ = fmt.Fprintln(w, "dropped")
+	s.Empty(testLog.buf)
+}
+
+func (s *ToolTestSuite) TestParseLogLevel() {
+	for _, level := range []LogLevel{LevelDebug, LevelInfo, LevelWarn, LevelError} {
+		parsed, err := ParseLogLevel(strings.ToLower(level.String()))
+		s.NoError(err)
+		s.Equal(level, parsed)
+	}
+	_, err := ParseLogLevel("LogLevel(7)")
+	s.Error(err)
+}
@@ -0,0 +1,254 @@
+package tool
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+)
+
+var (
+	// ErrInvalidMoney Returned for malformed amounts or currency codes
+	ErrInvalidMoney = errors.New("invalid money")
+	// ErrCurrencyMismatch Returned by operations on amounts in different currencies
+	ErrCurrencyMismatch = errors.New("currency mismatch")
+	// ErrMoneyOverflow Returned when the result doesn't fit int64 minor units
+	ErrMoneyOverflow = errors.New("money overflow")
+)
+
+// CurrencyDigits Number of minor unit digits of currencies not having the default 2
+var CurrencyDigits = map[string]int{
+	"BHD": 3, "BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "IQD": 3, "ISK": 0, "JOD": 3, "JPY": 0, "KMF": 0,
+	"KRW": 0, "KWD": 3, "LYD": 3, "OMR": 3, "PYG": 0, "RWF": 0, "TND": 3, "UGX": 0, "VND": 0, "VUV": 0,
+	"XAF": 0, "XOF": 0, "XPF": 0,
+}
+
+// Money Amount in minor units of the currency, e.g. cents, so arithmetic never loses precision.
+// Serialized to JSON as {"amount": 1234, "currency": "EUR"}, decodes "12.34 EUR" strings as well.
+type Money struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// NewMoney Returns money of minor units in the upper-cased currency
+func NewMoney(minor int64, currency string) Money {
+	return Money{Amount: minor, Currency: strings.ToUpper(currency)}
+}
+
+// ParseMoney Parses "12.34 EUR" or "EUR 12.34", the fraction must not exceed currency minor digits
+func ParseMoney(s string) (Money, error) {
+	parts := strings.Fields(s)
+	if len(parts) != 2 {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidMoney, s)
+	}
+	amount, currency := parts[0], parts[1]
+	if isCurrencyCode(strings.ToUpper(amount)) {
+		amount, currency = currency, amount
+	}
+	currency = strings.ToUpper(currency)
+	if !isCurrencyCode(currency) {
+		return Money{}, fmt.Errorf("%w: currency %q", ErrInvalidMoney, currency)
+	}
+
+	digits := currencyDigits(currency)
+	negative := strings.HasPrefix(amount, "-")
+	if negative || strings.HasPrefix(amount, "+") {
+		amount = amount[1:]
+	}
+	whole, frac, _ := strings.Cut(amount, ".")
+	if whole == "" || len(frac) > digits || !isDigits(whole) || !isDigits(frac) {
+		return Money{}, fmt.Errorf("%w: amount %q", ErrInvalidMoney, parts[0])
+	}
+	frac += strings.Repeat("0", digits-len(frac))
+
+	minor, ok := new(big.Int).SetString(whole+frac, 10)
+	if !ok || !minor.IsInt64() {
+		return Money{}, fmt.Errorf("%w: %q", ErrMoneyOverflow, s)
+	}
+	m := Money{Amount: minor.Int64(), Currency: currency}
+	if negative {
+		m.Amount = -m.Amount
+	}
+	return m, nil
+}
+
+// String Returns amount with currency, e.g. "12.34 EUR"
+func (m Money) String() string {
+	digits := currencyDigits(m.Currency)
+	abs := new(big.Int).Abs(big.NewInt(m.Amount)).String()
+	if len(abs) <= digits {
+		abs = strings.Repeat("0", digits-len(abs)+1) + abs
+	}
+	sign := ""
+	if m.Amount < 0 {
+		sign = "-"
+	}
+	if digits == 0 {
+		return sign + abs + " " + m.Currency
+	}
+	return sign + abs[:len(abs)-digits] + "." + abs[len(abs)-digits:] + " " + m.Currency
+}
+
+// UnmarshalJSON Implements json.Unmarshaler, accepts the object form or ParseMoney string, null is a no-op
+func (m *Money) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		parsed, err := ParseMoney(s)
+		if err != nil {
+			return err
+		}
+		*m = parsed
+		return nil
+	}
+	type plain Money
+	if err := json.Unmarshal(b, (*plain)(m)); err != nil {
+		return err
+	}
+	m.Currency = strings.ToUpper(m.Currency)
+	return nil
+}
+
+// IsZero Reports whether the amount is zero
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+// Neg Returns the amount with opposite sign
+func (m Money) Neg() (Money, error) {
+	if m.Amount == math.MinInt64 {
+		return Money{}, ErrMoneyOverflow
+	}
+	return Money{Amount: -m.Amount, Currency: m.Currency}, nil
+}
+
+// Add Returns m+o, both must be in the same currency
+func (m Money) Add(o Money) (Money, error) {
+	if err := m.sameCurrency(o); err != nil {
+		return Money{}, err
+	}
+	if o.Amount > 0 && m.Amount > math.MaxInt64-o.Amount || o.Amount < 0 && m.Amount < math.MinInt64-o.Amount {
+		return Money{}, ErrMoneyOverflow
+	}
+	return Money{Amount: m.Amount + o.Amount, Currency: m.Currency}, nil
+}
+
+// Sub Returns m-o, both must be in the same currency
+func (m Money) Sub(o Money) (Money, error) {
+	neg, err := o.Neg()
+	if err != nil {
+		return Money{}, err
+	}
+	return m.Add(neg)
+}
+
+// Mul Returns the amount multiplied by n
+func (m Money) Mul(n int64) (Money, error) {
+	res := new(big.Int).Mul(big.NewInt(m.Amount), big.NewInt(n))
+	if !res.IsInt64() {
+		return Money{}, ErrMoneyOverflow
+	}
+	return Money{Amount: res.Int64(), Currency: m.Currency}, nil
+}
+
+// Cmp Returns -1, 0 or 1 if m is less, equal or greater than o, both must be in the same currency
+func (m Money) Cmp(o Money) (int, error) {
+	if err := m.sameCurrency(o); err != nil {
+		return 0, err
+	}
+	switch {
+	case m.Amount < o.Amount:
+		return -1, nil
+	case m.Amount > o.Amount:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// Split Divides the amount into n parts differing by at most one minor unit, first parts get the remainder
+func (m Money) Split(n int) ([]Money, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("%w: split into %d parts", ErrInvalidMoney, n)
+	}
+	ratios := make([]int, n)
+	for i := range ratios {
+		ratios[i] = 1
+	}
+	return m.Allocate(ratios...)
+}
+
+// Allocate Divides the amount proportionally to non-negative ratios without losing minor units,
+// the remainder is spread one unit at a time starting from the first part, e.g. 1.00 by 1:1:1 is 0.34, 0.33, 0.33
+func (m Money) Allocate(ratios ...int) ([]Money, error) {
+	total := big.NewInt(0)
+	for _, ratio := range ratios {
+		if ratio < 0 {
+			return nil, fmt.Errorf("%w: negative ratio %d", ErrInvalidMoney, ratio)
+		}
+		total.Add(total, big.NewInt(int64(ratio)))
+	}
+	if total.Sign() == 0 {
+		return nil, fmt.Errorf("%w: ratios sum to zero", ErrInvalidMoney)
+	}
+
+	parts := make([]Money, len(ratios))
+	remainder := m.Amount
+	for i, ratio := range ratios {
+		share := new(big.Int).Mul(big.NewInt(m.Amount), big.NewInt(int64(ratio)))
+		share.Quo(share, total)
+		parts[i] = Money{Amount: share.Int64(), Currency: m.Currency}
+		remainder -= parts[i].Amount
+	}
+
+	unit := int64(1)
+	if remainder < 0 {
+		unit = -1
+	}
+	for i := 0; remainder != 0; i = (i + 1) % len(parts) {
+		if ratios[i] == 0 {
+			continue
+		}
+		parts[i].Amount += unit
+		remainder -= unit
+	}
+	return parts, nil
+}
+
+func (m Money) sameCurrency(o Money) error {
+	if m.Currency != o.Currency {
+		return fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, o.Currency)
+	}
+	return nil
+}
+
+func currencyDigits(currency string) int {
+	if digits, ok := CurrencyDigits[currency]; ok {
+		return digits
+	}
+	return 2
+}
+
+func isCurrencyCode(s string) bool {
+	if len(s) != 3 {
+		return false
+	}
+	for _, r := range s {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,104 @@
+package tool
+
+import (
+	"encoding/json"
+	"math"
+)
+
+func (s *ToolTestSuite) TestMoney() {
+	eur := func(minor int64) Money { return NewMoney(minor, "eur") }
+
+	s.Run("parse and format", func() {
+		for in, want := range map[string]Money{
+			"12.34 EUR": eur(1234),
+			"EUR 12.3":  eur(1230),
+			"-0.05 eur": eur(-5),
+			"+7 USD":    NewMoney(700, "USD"),
+			"1500 JPY":  NewMoney(1500, "JPY"),
+			"1.005 KWD": NewMoney(1005, "KWD"),
+			"0.00 EUR":  eur(0),
+		} {
+			m, err := ParseMoney(in)
+			s.NoError(err, in)
+			s.Equal(want, m, in)
+		}
+		s.Equal("12.34 EUR", eur(1234).String())
+		s.Equal("-0.05 EUR", eur(-5).String())
+		s.Equal("1500 JPY", NewMoney(1500, "JPY").String())
+		s.Equal("-92233720368547758.08 EUR", eur(math.MinInt64).String())
+
+		for _, in := range []string{"12.345 EUR", "12 EURO", "1,5 EUR", "EUR", ". EUR", "1.5 JPY", "1.5 EUR extra", "+-5 EUR", "--5 EUR"} {
+			_, err := ParseMoney(in)
+			s.ErrorIs(err, ErrInvalidMoney, in)
+		}
+		_, err := ParseMoney("99999999999999999999 EUR")
+		s.ErrorIs(err, ErrMoneyOverflow)
+	})
+
+	s.Run("arithmetic", func() {
+		sum, err := eur(150).Add(eur(-50))
+		s.NoError(err)
+		s.Equal(eur(100), sum)
+		diff, err := eur(100).Sub(eur(250))
+		s.NoError(err)
+		s.Equal(eur(-150), diff)
+		prod, err := eur(-25).Mul(4)
+		s.NoError(err)
+		s.Equal(eur(-100), prod)
+		cmp, err := eur(1).Cmp(eur(2))
+		s.NoError(err)
+		s.Equal(-1, cmp)
+		s.True(eur(0).IsZero())
+
+		_, err = eur(1).Add(NewMoney(1, "USD"))
+		s.ErrorIs(err, ErrCurrencyMismatch)
+		_, err = eur(math.MaxInt64).Add(eur(1))
+		s.ErrorIs(err, ErrMoneyOverflow)
+		_, err = eur(math.MinInt64).Sub(eur(1))
+		s.ErrorIs(err, ErrMoneyOverflow)
+		_, err = eur(math.MaxInt64 / 2).Mul(3)
+		s.ErrorIs(err, ErrMoneyOverflow)
+	})
+
+	s.Run("allocation", func() {
+		parts, err := eur(100).Split(3)
+		s.NoError(err)
+		s.Equal([]Money{eur(34), eur(33), eur(33)}, parts)
+
+		parts, err = eur(-100).Split(3)
+		s.NoError(err)
+		s.Equal([]Money{eur(-34), eur(-33), eur(-33)}, parts)
+
+		parts, err = eur(1001).Allocate(0, 70, 30)
+		s.NoError(err)
+		s.Equal([]Money{eur(0), eur(701), eur(300)}, parts)
+
+		parts, err = eur(math.MaxInt64).Allocate(1, 1)
+		s.NoError(err)
+		total, err := parts[0].Add(parts[1])
+		s.NoError(err)
+		s.Equal(eur(math.MaxInt64), total)
+
+		_, err = eur(1).Split(0)
+		s.ErrorIs(err, ErrInvalidMoney)
+		_, err = eur(1).Allocate(0, 0)
+		s.ErrorIs(err, ErrInvalidMoney)
+		_, err = eur(1).Allocate(1, -1)
+		s.ErrorIs(err, ErrInvalidMoney)
+	})
+
+	s.Run("json", func() {
+		s.Equal(Varchar(`{"amount":1234,"currency":"EUR"}`), Jsonify(eur(1234)))
+
+		var order struct {
+			Total Money `json:"total"`
+			Fee   Money `json:"fee"`
+		}
+		s.NoError(json.Unmarshal([]byte(`{"total":"12.34 EUR","fee":{"amount":5,"currency":"eur"}}`), &order))
+		s.Equal(eur(1234), order.Total)
+		s.Equal(eur(5), order.Fee)
+		s.ErrorIs(json.Unmarshal([]byte(`{"total":"12.345 EUR"}`), &order), ErrInvalidMoney)
+		s.NoError(json.Unmarshal([]byte(`{"total":null}`), &order))
+		s.Equal(eur(1234), order.Total)
+	})
+}
@@ -0,0 +1,97 @@
+package tool
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"strings"
+)
+
+var (
+	// ErrInvalidEmail Returned for malformed email addresses
+	ErrInvalidEmail = errors.New("invalid email")
+	// ErrInvalidURL Returned for malformed or non-absolute URLs
+	ErrInvalidURL = errors.New("invalid url")
+	// ErrInvalidPhone Returned for numbers not representable in E.164
+	ErrInvalidPhone = errors.New("invalid phone")
+)
+
+// defaultPorts Ports stripped by NormalizeURL for their schemes
+var defaultPorts = map[string]string{"http": "80", "https": "443", "ws": "80", "wss": "443", "ftp": "21"}
+
+// ValidateEmail Checks s is a bare address like "user@example.com", without display name or angle brackets
+func ValidateEmail(s string) error {
+	addr, err := mail.ParseAddress(s)
+	if err != nil || addr.Name != "" || addr.Address != s {
+		return fmt.Errorf("%w: %q", ErrInvalidEmail, s)
+	}
+	_, domain, _ := strings.Cut(addr.Address, "@")
+	if !strings.Contains(strings.Trim(domain, "."), ".") {
+		return fmt.Errorf("%w: %q has no top-level domain", ErrInvalidEmail, s)
+	}
+	return nil
+}
+
+// NormalizeEmail Returns trimmed s with lower-cased domain, the local part is kept as is since it may be case-sensitive
+func NormalizeEmail(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	if i := strings.LastIndexByte(s, '@'); i >= 0 {
+		s = s[:i] + strings.ToLower(s[i:])
+	}
+	if err := ValidateEmail(s); err != nil {
+		return "", err
+	}
+	return s, nil
+}
+
+// NormalizeURL Returns absolute URL with lower-cased scheme and host, default port stripped and empty path set to "/"
+func NormalizeURL(s string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(s))
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidURL, err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return "", fmt.Errorf("%w: %q is not absolute", ErrInvalidURL, s)
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+	host, port := strings.ToLower(u.Hostname()), u.Port()
+	switch {
+	case port != "" && port != defaultPorts[u.Scheme]:
+		u.Host = net.JoinHostPort(host, port)
+	case strings.Contains(host, ":"):
+		u.Host = "[" + host + "]"
+	default:
+		u.Host = host
+	}
+	if u.Path == "" && u.Opaque == "" {
+		u.Path = "/"
+	}
+	return u.String(), nil
+}
+
+// E164Phone Returns phone number in E.164 format, e.g. "+14155550123".
+// Spaces, dots, dashes and parentheses are ignored, the number must start with "+" or "00" followed by country code.
+func E164Phone(s string) (string, error) {
+	digits := strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '.', '-', '(', ')':
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(s))
+
+	switch {
+	case strings.HasPrefix(digits, "+"):
+		digits = digits[1:]
+	case strings.HasPrefix(digits, "00"):
+		digits = digits[2:]
+	default:
+		return "", fmt.Errorf("%w: %q has no country code", ErrInvalidPhone, s)
+	}
+	if len(digits) < 7 || len(digits) > 15 || digits[0] == '0' || !isDigits(digits) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidPhone, s)
+	}
+	return "+" + digits, nil
+}
@@ -0,0 +1,50 @@
+package tool
+
+func (s *ToolTestSuite) TestNormalize() {
+	s.Run("email", func() {
+		email, err := NormalizeEmail("  John.Doe@Example.COM ")
+		s.NoError(err)
+		s.Equal("John.Doe@example.com", email)
+
+		for _, in := range []string{"", "john", "john@localhost", "John <john@example.com>", "a@b@example.com"} {
+			_, err := NormalizeEmail(in)
+			s.ErrorIs(err, ErrInvalidEmail, in)
+		}
+		s.NoError(ValidateEmail("a+tag@mail.example.org"))
+	})
+
+	s.Run("url", func() {
+		for in, want := range map[string]string{
+			"HTTP://Example.COM:80":         "http://example.com/",
+			"https://example.com:443/a?b=C": "https://example.com/a?b=C",
+			"https://EXAMPLE.com:8443/Path": "https://example.com:8443/Path",
+			"http://[::1]:80/x":             "http://[::1]/x",
+			"http://[::1]:8080":             "http://[::1]:8080/",
+			"wss://Host/socket":             "wss://host/socket",
+		} {
+			got, err := NormalizeURL(in)
+			s.NoError(err, in)
+			s.Equal(want, got, in)
+		}
+		for _, in := range []string{"example.com/path", "/relative", "http://%zz", "mailto:a@b.c"} {
+			_, err := NormalizeURL(in)
+			s.ErrorIs(err, ErrInvalidURL, in)
+		}
+	})
+
+	s.Run("phone", func() {
+		for in, want := range map[string]string{
+			"+1 (415) 555-0123": "+14155550123",
+			"0044 20 7946 0958": "+442079460958",
+			"+7.912.345.67.89":  "+79123456789",
+		} {
+			got, err := E164Phone(in)
+			s.NoError(err, in)
+			s.Equal(want, got, in)
+		}
+		for _, in := range []string{"415 555 0123", "+0123456789", "+1 415", "+1234567890123456", "+1 415 555 O123"} {
+			_, err := E164Phone(in)
+			s.ErrorIs(err, ErrInvalidPhone, in)
+		}
+	})
+}
@@ -0,0 +1,40 @@
+package tool
+
+// Option Functional option configuring T, the shared mechanism of configurable APIs
+type Option[T any] func(*T)
+
+// Apply Applies options to cfg in order, nil options are skipped.
+// Then validates cfg, if *T implements Validator.
+func Apply[T any](cfg *T, opts ...Option[T]) error {
+	for _, opt := range opts {
+		if opt != nil {
+			opt(cfg)
+		}
+	}
+	return validate(cfg)
+}
+
+// Build Returns a copy of base with options applied and validated
+func Build[T any](base T, opts ...Option[T]) (T, error) {
+	err := Apply(&base, opts...)
+	return base, err
+}
+
+// Combine Returns a single option applying all the given options in order
+func Combine[T any](opts ...Option[T]) Option[T] {
+	return func(cfg *T) {
+		for _, opt := range opts {
+			if opt != nil {
+				opt(cfg)
+			}
+		}
+	}
+}
+
+// When Returns opt if cond is true, otherwise a no-op option
+func When[T any](cond bool, opt Option[T]) Option[T] {
+	if cond {
+		return opt
+	}
+	return func(*T) {}
+}
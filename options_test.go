@@ -0,0 +1,61 @@
+package tool
+
+import (
+	"errors"
+)
+
+type testOptions struct {
+	Name    string
+	Workers int
+}
+
+func (o testOptions) Validate() error {
+	if o.Workers < 0 {
+		return errTestOptions
+	}
+	return nil
+}
+
+var errTestOptions = errors.New("workers must not be negative")
+
+func withWorkers(n int) Option[testOptions] {
+	return func(o *testOptions) { o.Workers = n }
+}
+
+func withName(name string) Option[testOptions] {
+	return func(o *testOptions) { o.Name = name }
+}
+
+func (s *ToolTestSuite) TestApply() {
+	s.Run("in order", func() {
+		cfg := testOptions{Workers: 1}
+		s.NoError(Apply(&cfg, withWorkers(2), nil, withWorkers(3)))
+		s.Equal(3, cfg.Workers)
+	})
+	s.Run("validation", func() {
+		cfg := testOptions{}
+		s.ErrorIs(Apply(&cfg, withWorkers(-1)), errTestOptions)
+	})
+}
+
+func (s *ToolTestSuite) TestBuild() {
+	base := testOptions{Name: "base", Workers: 1}
+	cfg, err := Build(base, withName("custom"))
+	s.NoError(err)
+	s.Equal(testOptions{Name: "custom", Workers: 1}, cfg)
+	s.Equal("base", base.Name)
+}
+
+func (s *ToolTestSuite) TestCombineWhen() {
+	cfg, err := Build(testOptions{}, Combine(
+		withName("combined"),
+		When(false, withWorkers(5)),
+		When(true, withWorkers(2)),
+	))
+	s.NoError(err)
+	s.Equal(testOptions{Name: "combined", Workers: 2}, cfg)
+
+	noop := When(false, withWorkers(5))
+	s.NotPanics(func() { noop(&cfg) })
+	s.Equal(2, cfg.Workers)
+}
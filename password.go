@@ -0,0 +1,131 @@
+package tool
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Argon2Params Cost parameters of argon2id password hashes
+type Argon2Params struct {
+	Memory  uint32 // KiB
+	Time    uint32
+	Threads uint8
+	SaltLen uint32
+	KeyLen  uint32
+}
+
+var (
+	// ErrPasswordMismatch Returned by CheckPassword when password doesn't match the hash
+	ErrPasswordMismatch = errors.New("password mismatch")
+
+	// PasswordParams Parameters of new hashes, hashes with other parameters are reported by NeedsRehash.
+	// Memory, Time and Threads must stay within the limits CheckPassword accepts, 1 GiB, 16 and 255
+	PasswordParams = Argon2Params{Memory: 64 << 10, Time: 1, Threads: 4, SaltLen: 16, KeyLen: 32}
+
+	passwordEncoding = base64.RawStdEncoding
+)
+
+// Upper bounds of costs accepted from stored hashes, so a crafted hash can't make CheckPassword
+// allocate gigabytes or spin for hours, Threads is capped at 255 by its type
+const (
+	maxArgon2Memory = 1 << 20 // KiB, 1 GiB
+	maxArgon2Time   = 16
+)
+
+// HashPassword Returns argon2id hash of the password in PHC string format using PasswordParams
+func HashPassword(pw string) (string, error) {
+	p := PasswordParams
+	salt := make([]byte, p.SaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	key := argon2.IDKey([]byte(pw), salt, p.Time, p.Memory, p.Threads, p.KeyLen)
+	return fmt.Sprintf(
+		"$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, p.Memory, p.Time, p.Threads,
+		passwordEncoding.EncodeToString(salt), passwordEncoding.EncodeToString(key),
+	), nil
+}
+
+// CheckPassword Returns nil if password matches argon2id or bcrypt hash, ErrPasswordMismatch if it doesn't
+func CheckPassword(hash, pw string) error {
+	if isBcrypt(hash) {
+		err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(pw))
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return ErrPasswordMismatch
+		}
+		return err
+	}
+
+	p, salt, key, err := parseArgon2Hash(hash)
+	if err != nil {
+		return err
+	}
+	if !SecureEqual(key, argon2.IDKey([]byte(pw), salt, p.Time, p.Memory, p.Threads, p.KeyLen)) {
+		return ErrPasswordMismatch
+	}
+	return nil
+}
+
+// NeedsRehash Reports whether the hash was made by another algorithm or with parameters other than PasswordParams,
+// call it after successful CheckPassword to upgrade stored hashes on login
+func NeedsRehash(hash string) bool {
+	if isBcrypt(hash) {
+		return true
+	}
+	p, _, _, err := parseArgon2Hash(hash)
+	return err != nil || p != PasswordParams
+}
+
+// CheckPasswordUpgrade Checks the password and returns a fresh hash if NeedsRehash, empty string otherwise
+func CheckPasswordUpgrade(hash, pw string) (newHash string, err error) {
+	if err = CheckPassword(hash, pw); err != nil {
+		return "", err
+	}
+	if !NeedsRehash(hash) {
+		return "", nil
+	}
+	return HashPassword(pw)
+}
+
+func isBcrypt(hash string) bool {
+	return strings.HasPrefix(hash, "$2a$") || strings.HasPrefix(hash, "$2b$") || strings.HasPrefix(hash, "$2y$")
+}
+
+func parseArgon2Hash(hash string) (p Argon2Params, salt, key []byte, err error) {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return p, nil, nil, errors.New("unsupported password hash format")
+	}
+	var version int
+	if _, err = fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return p, nil, nil, fmt.Errorf("unsupported argon2 version %q", parts[2])
+	}
+	if _, err = fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.Memory, &p.Time, &p.Threads); err != nil {
+		return p, nil, nil, fmt.Errorf("invalid argon2 parameters: %w", err)
+	}
+	if salt, err = passwordEncoding.DecodeString(parts[4]); err != nil {
+		return p, nil, nil, fmt.Errorf("invalid argon2 salt: %w", err)
+	}
+	if key, err = passwordEncoding.DecodeString(parts[5]); err != nil {
+		return p, nil, nil, fmt.Errorf("invalid argon2 key: %w", err)
+	}
+	p.SaltLen, p.KeyLen = uint32(len(salt)), uint32(len(key))
+	switch {
+	case p.Time == 0, p.Threads == 0, p.Time > maxArgon2Time:
+		return p, nil, nil, fmt.Errorf("argon2 parameters out of range: %s", parts[3])
+	case p.Memory < 8*uint32(p.Threads) || p.Memory > maxArgon2Memory:
+		return p, nil, nil, fmt.Errorf("argon2 memory out of range: %d KiB", p.Memory)
+	case len(salt) == 0:
+		return p, nil, nil, errors.New("invalid argon2 salt: empty")
+	case len(key) == 0:
+		return p, nil, nil, errors.New("invalid argon2 key: empty")
+	}
+	return p, salt, key, nil
+}
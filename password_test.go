@@ -0,0 +1,79 @@
+package tool
+
+import (
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+func (s *ToolTestSuite) TestHashPassword() {
+	params := PasswordParams
+	defer func() { PasswordParams = params }()
+	PasswordParams.Memory = 1 << 10
+
+	hash, err := HashPassword("correct horse")
+	s.Require().NoError(err)
+	s.True(strings.HasPrefix(hash, "$argon2id$v=19$m=1024,t=1,p=4$"))
+	s.NotEqual(hash, MustReturn(HashPassword("correct horse")))
+
+	s.Run("check", func() {
+		s.NoError(CheckPassword(hash, "correct horse"))
+		s.ErrorIs(CheckPassword(hash, "battery staple"), ErrPasswordMismatch)
+		s.Error(CheckPassword("$md5$whatever", "correct horse"))
+	})
+
+	s.Run("rehash", func() {
+		s.False(NeedsRehash(hash))
+		newHash, err := CheckPasswordUpgrade(hash, "correct horse")
+		s.NoError(err)
+		s.Empty(newHash)
+
+		PasswordParams.Time = 2
+		s.True(NeedsRehash(hash))
+		newHash, err = CheckPasswordUpgrade(hash, "correct horse")
+		s.NoError(err)
+		s.Contains(newHash, "t=2")
+		s.NoError(CheckPassword(newHash, "correct horse"))
+
+		_, err = CheckPasswordUpgrade(hash, "wrong")
+		s.ErrorIs(err, ErrPasswordMismatch)
+	})
+
+	s.Run("bcrypt", func() {
+		legacy := string(MustReturn(bcrypt.GenerateFromPassword([]byte("legacy"), bcrypt.MinCost)))
+		s.NoError(CheckPassword(legacy, "legacy"))
+		s.ErrorIs(CheckPassword(legacy, "other"), ErrPasswordMismatch)
+		s.True(NeedsRehash(legacy))
+	})
+}
+
+func (s *ToolTestSuite) TestCheckPasswordMalformed() {
+	const salt, key = "c2FsdHNhbHRzYWx0c2FsdA", "a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2U"
+	for name, hash := range map[string]string{
+		"zero time":      "$argon2id$v=19$m=1024,t=0,p=1$" + salt + "$" + key,
+		"zero threads":   "$argon2id$v=19$m=1024,t=1,p=0$" + salt + "$" + key,
+		"low memory":     "$argon2id$v=19$m=7,t=1,p=1$" + salt + "$" + key,
+		"huge memory":    "$argon2id$v=19$m=4294967295,t=1,p=1$" + salt + "$" + key,
+		"memory above":   "$argon2id$v=19$m=1048577,t=1,p=1$" + salt + "$" + key,
+		"time above":     "$argon2id$v=19$m=1024,t=17,p=1$" + salt + "$" + key,
+		"huge time":      "$argon2id$v=19$m=1024,t=4294967295,p=1$" + salt + "$" + key,
+		"threads above":  "$argon2id$v=19$m=4096,t=1,p=256$" + salt + "$" + key,
+		"empty salt":     "$argon2id$v=19$m=1024,t=1,p=1$$" + key,
+		"empty key":      "$argon2id$v=19$m=1024,t=1,p=1$" + salt + "$",
+		"bad parameters": "$argon2id$v=19$m=x,t=1,p=1$" + salt + "$" + key,
+	} {
+		s.NotPanics(func() {
+			err := CheckPassword(hash, "pw")
+			s.Error(err, name)
+			s.NotErrorIs(err, ErrPasswordMismatch, name)
+		}, name)
+		s.True(NeedsRehash(hash), name)
+	}
+
+	for name, hash := range map[string]string{
+		"max time":    "$argon2id$v=19$m=8,t=16,p=1$" + salt + "$" + key,
+		"max threads": "$argon2id$v=19$m=2040,t=1,p=255$" + salt + "$" + key,
+	} {
+		s.ErrorIs(CheckPassword(hash, "pw"), ErrPasswordMismatch, name)
+	}
+}
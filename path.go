@@ -0,0 +1,192 @@
+package tool
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// ErrPathTraversal Returned by JoinSecure when the result would point outside the base directory
+var ErrPathTraversal = errors.New("path escapes base directory")
+
+// JoinSecure Joins untrusted path elements to base and rejects results escaping it,
+// either lexically ("../") or through symlinks existing on disk
+func JoinSecure(base string, unsafe ...string) (string, error) {
+	base = filepath.Clean(base)
+	joined := filepath.Join(append([]string{base}, unsafe...)...)
+	if !isWithin(base, joined) {
+		return "", fmt.Errorf("%w: %s", ErrPathTraversal, filepath.Join(unsafe...))
+	}
+
+	realBase, err := resolveExisting(base)
+	if err != nil {
+		return "", err
+	}
+	realJoined, err := resolveExisting(joined)
+	if err != nil {
+		return "", err
+	}
+	if !isWithin(realBase, realJoined) {
+		return "", fmt.Errorf("%w: %s resolves to %s", ErrPathTraversal, filepath.Join(unsafe...), realJoined)
+	}
+	return joined, nil
+}
+
+// isWithin Checks lexically that target is base or is inside it
+func isWithin(base, target string) bool {
+	rel, err := filepath.Rel(base, target)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) && !filepath.IsAbs(rel)
+}
+
+// resolveExisting Evaluates symlinks of the longest existing prefix of the path, keeping the rest as is
+func resolveExisting(path string) (string, error) {
+	var rest []string
+	for current := path; ; {
+		resolved, err := filepath.EvalSymlinks(current)
+		if err == nil {
+			return filepath.Join(append([]string{resolved}, rest...)...), nil
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return "", err
+		}
+		parent := filepath.Dir(current)
+		if parent == current {
+			return path, nil
+		}
+		rest = append([]string{filepath.Base(current)}, rest...)
+		current = parent
+	}
+}
+
+// PathStrategy Returns a base directory for GetRelativePath, ok is false if the strategy can't tell one
+type PathStrategy func() (base string, ok bool)
+
+// DefaultPathStrategies Fallback chain of GetRelativePath used unless SetPathStrategies is called
+var DefaultPathStrategies = []PathStrategy{PathFromCallerFile, PathFromModuleRoot, PathFromWorkDir}
+
+var pathBase struct {
+	mu         sync.RWMutex
+	explicit   string
+	strategies []PathStrategy
+}
+
+// SetPathBase Sets explicit base directory of GetRelativePath, tried before the strategies, empty to unset
+func SetPathBase(dir string) {
+	pathBase.mu.Lock()
+	defer pathBase.mu.Unlock()
+	pathBase.explicit = dir
+}
+
+// SetPathStrategies Replaces fallback chain of GetRelativePath, no strategies restore DefaultPathStrategies
+func SetPathStrategies(strategies ...PathStrategy) {
+	pathBase.mu.Lock()
+	defer pathBase.mu.Unlock()
+	pathBase.strategies = strategies
+}
+
+// GetRelativePath Returns filePath relative to the first base containing it: the one set with SetPathBase,
+// then the ones given by the strategies in order. Returns filePath as is if no base contains it.
+func GetRelativePath(filePath string) string {
+	pathBase.mu.RLock()
+	explicit, strategies := pathBase.explicit, pathBase.strategies
+	pathBase.mu.RUnlock()
+	if len(strategies) == 0 {
+		strategies = DefaultPathStrategies
+	}
+
+	bases := []PathStrategy{func() (string, bool) { return explicit, explicit != "" }}
+	for _, strategy := range append(bases, strategies...) {
+		base, ok := strategy()
+		if !ok || !isWithin(base, filePath) {
+			continue
+		}
+		if rel, err := filepath.Rel(base, filePath); err == nil {
+			return rel
+		}
+	}
+	return filePath
+}
+
+// PathFromCallerFile Returns directory of the RootCallerInfo source file, e.g. of main.go.
+// Fails in binaries deployed without sources.
+func PathFromCallerFile() (string, bool) {
+	file := findRootCaller()
+	if file == "" {
+		return "", false
+	}
+	if _, err := os.Stat(file); err != nil {
+		return "", false
+	}
+	return filepath.Dir(file), true
+}
+
+// PathFromModuleRoot Returns the closest directory containing go.mod, starting from the working directory
+func PathFromModuleRoot() (string, bool) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", false
+	}
+	for {
+		if _, err = os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return dir, true
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+// PathFromWorkDir Returns the working directory
+func PathFromWorkDir() (string, bool) {
+	dir, err := os.Getwd()
+	return dir, err == nil
+}
+
+// CallerInfo Source position of a function call
+type CallerInfo struct {
+	Function string
+	File     string
+	Line     int
+}
+
+// rootCallers Resolved CallerInfo of RootCallerInfo by program counter of the call site
+var rootCallers sync.Map
+
+// RootCallerInfo Returns the outermost caller outside GOROOT and this package, e.g. main.main.
+// The result is cached per call site of the package.
+func RootCallerInfo() CallerInfo {
+	pc, _ := externalCaller()
+	if info, ok := rootCallers.Load(pc); ok && pc != 0 {
+		return info.(CallerInfo)
+	}
+
+	var pcs [64]uintptr
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(1, pcs[:])])
+	goroot := filepath.ToSlash(runtime.GOROOT())
+	var info CallerInfo
+	for {
+		frame, more := frames.Next()
+		inGoroot := goroot != "" && strings.HasPrefix(filepath.ToSlash(frame.File), goroot+"/")
+		if frame.File != "" && !inGoroot && !isToolFrame(frame) {
+			info = CallerInfo{Function: frame.Function, File: frame.File, Line: frame.Line}
+		}
+		if !more {
+			break
+		}
+	}
+	if pc != 0 {
+		rootCallers.Store(pc, info)
+	}
+	return info
+}
+
+// findRootCaller Returns file of RootCallerInfo
+func findRootCaller() string {
+	return RootCallerInfo().File
+}
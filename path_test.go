@@ -0,0 +1,86 @@
+package tool
+
+import (
+	"os"
+	"path/filepath"
+)
+
+func (s *ToolTestSuite) TestJoinSecure() {
+	tmp := s.T().TempDir()
+	base := filepath.Join(tmp, "base")
+	outside := filepath.Join(tmp, "outside")
+	s.Require().NoError(os.MkdirAll(filepath.Join(base, "inner"), 0o755))
+	s.Require().NoError(os.MkdirAll(outside, 0o755))
+
+	s.Run("inside", func() {
+		for _, parts := range [][]string{
+			{"a", "b.txt"},
+			{"inner", "..", "c.txt"},
+			{"/etc/passwd"},
+			{},
+		} {
+			res, err := JoinSecure(base, parts...)
+			s.NoError(err, parts)
+			s.True(isWithin(base, res), res)
+		}
+	})
+
+	s.Run("traversal", func() {
+		for _, parts := range [][]string{
+			{".."},
+			{"../outside/x"},
+			{"inner", "../../x"},
+		} {
+			_, err := JoinSecure(base, parts...)
+			s.ErrorIs(err, ErrPathTraversal, parts)
+		}
+	})
+
+	s.Run("symlink", func() {
+		if err := os.Symlink(outside, filepath.Join(base, "link")); err != nil {
+			s.T().Skip("symlinks are not supported:", err)
+		}
+		_, err := JoinSecure(base, "link", "file.txt")
+		s.ErrorIs(err, ErrPathTraversal)
+
+		s.Require().NoError(os.Symlink(filepath.Join(base, "inner"), filepath.Join(base, "inlink")))
+		_, err = JoinSecure(base, "inlink", "file.txt")
+		s.NoError(err)
+	})
+}
+
+func (s *ToolTestSuite) TestGetRelativePath() {
+	defer SetPathBase("")
+	defer SetPathStrategies()
+
+	wd, err := os.Getwd()
+	s.Require().NoError(err)
+	file := filepath.Join(wd, "sub", "file.go")
+
+	s.Equal(filepath.Join("sub", "file.go"), GetRelativePath(file))
+
+	SetPathBase(filepath.Join(wd, "sub"))
+	s.Equal("file.go", GetRelativePath(file))
+	s.Equal("/elsewhere/x.go", GetRelativePath("/elsewhere/x.go"))
+
+	SetPathBase("")
+	SetPathStrategies(func() (string, bool) { return "", false }, PathFromWorkDir)
+	s.Equal(filepath.Join("sub", "file.go"), GetRelativePath(file))
+
+	root, ok := PathFromModuleRoot()
+	s.True(ok)
+	s.Equal(wd, root)
+
+	callerDir, ok := PathFromCallerFile()
+	s.True(ok)
+	s.True(filepath.IsAbs(callerDir))
+}
+
+func (s *ToolTestSuite) TestRootCallerInfo() {
+	info := RootCallerInfo()
+	s.NotEmpty(info.File)
+	s.Positive(info.Line)
+	s.NotContains(info.Function, toolPackage+".")
+	s.Equal(info, RootCallerInfo())
+	s.Equal(info.File, findRootCaller())
+}
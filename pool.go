@@ -0,0 +1,91 @@
+package tool
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/iamwavecut/tool/safetool"
+)
+
+// ErrPoolClosed Returned by Pool.Go after Wait
+var ErrPoolClosed = errors.New("pool is closed")
+
+// Pool Runs jobs on a fixed number of worker goroutines.
+// Jobs run under Recoverer, so a panic fails only its own job, and all job errors are collected for Wait.
+type Pool struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	jobs   chan func(context.Context) error
+	wg     sync.WaitGroup
+
+	mu     sync.Mutex
+	closed bool
+
+	errMu sync.Mutex
+	errs  []error
+}
+
+// NewPool Starts a pool of workers goroutines, at least 1. Jobs receive a context derived from ctx,
+// which is cancelled once Wait returns
+func NewPool(ctx context.Context, workers int) *Pool {
+	workers = max(workers, 1)
+	p := &Pool{jobs: make(chan func(context.Context) error)}
+	p.ctx, p.cancel = context.WithCancel(ctx)
+	p.wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go p.work()
+	}
+	return p
+}
+
+// Go Submits the job, blocks until a worker is free. Returns ErrPoolClosed after Wait,
+// or ctx error if the pool context is done before the job is taken
+func (p *Pool) Go(job func(ctx context.Context) error) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return ErrPoolClosed
+	}
+	if err := p.ctx.Err(); err != nil {
+		return err
+	}
+	select {
+	case p.jobs <- job:
+		return nil
+	case <-p.ctx.Done():
+		return p.ctx.Err()
+	}
+}
+
+// Wait Stops accepting jobs, waits for the running ones and returns their errors combined, nil if all succeeded
+func (p *Pool) Wait() error {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.jobs)
+	}
+	p.mu.Unlock()
+
+	p.wg.Wait()
+	p.cancel()
+
+	p.errMu.Lock()
+	defer p.errMu.Unlock()
+	return safetool.CombineErrors(p.errs...)
+}
+
+func (p *Pool) work() {
+	defer p.wg.Done()
+	for job := range p.jobs {
+		var err error
+		if panicErr := Recoverer(0, func() { err = job(p.ctx) }, "pool"); panicErr != nil {
+			err = panicErr
+		}
+		if err != nil {
+			p.errMu.Lock()
+			p.errs = append(p.errs, err)
+			p.errMu.Unlock()
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package tool
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+func (s *ToolTestSuite) TestPool() {
+	s.Run("bounded concurrency", func() {
+		var running, peak, done int32
+		p := NewPool(context.Background(), 3)
+		for i := 0; i < 10; i++ {
+			s.NoError(p.Go(func(context.Context) error {
+				n := atomic.AddInt32(&running, 1)
+				for {
+					old := atomic.LoadInt32(&peak)
+					if n <= old || atomic.CompareAndSwapInt32(&peak, old, n) {
+						break
+					}
+				}
+				time.Sleep(5 * time.Millisecond)
+				atomic.AddInt32(&running, -1)
+				atomic.AddInt32(&done, 1)
+				return nil
+			}))
+		}
+		s.NoError(p.Wait())
+		s.Equal(int32(10), done)
+		s.LessOrEqual(peak, int32(3))
+		s.ErrorIs(p.Go(func(context.Context) error { return nil }), ErrPoolClosed)
+	})
+
+	s.Run("errors and panics", func() {
+		sentinel := errors.New("sentinel")
+		p := NewPool(context.Background(), 2)
+		s.NoError(p.Go(func(context.Context) error { return sentinel }))
+		s.NoError(p.Go(func(context.Context) error { panic("boom") }))
+		s.NoError(p.Go(func(context.Context) error { return nil }))
+
+		err := p.Wait()
+		s.ErrorIs(err, sentinel)
+		s.Contains(err.Error(), "boom")
+		s.Contains(testLog.buf, "boom")
+	})
+
+	s.Run("context", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		p := NewPool(ctx, 1)
+		started := make(chan struct{})
+		s.NoError(p.Go(func(ctx context.Context) error {
+			close(started)
+			<-ctx.Done()
+			return ctx.Err()
+		}))
+		<-started
+		cancel()
+		s.ErrorIs(p.Go(func(context.Context) error { return nil }), context.Canceled)
+		s.ErrorIs(p.Wait(), context.Canceled)
+	})
+}
+
+func (s *ToolTestSuite) TestPoolNegativeWorkers() {
+	var p *Pool
+	s.NotPanics(func() { p = NewPool(context.Background(), -1) })
+	var done atomic.Bool
+	s.NoError(p.Go(func(context.Context) error { done.Store(true); return nil }))
+	s.NoError(p.Wait())
+	s.True(done.Load())
+}
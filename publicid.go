@@ -0,0 +1,76 @@
+package tool
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// publicIDAlphabet Characters of identifiers produced by EncodeID
+const publicIDAlphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// ErrInvalidID Returned by DecodeID for identifiers not produced by EncodeID with the same salt
+var ErrInvalidID = errors.New("invalid id")
+
+// EncodeID Returns short alphanumeric identifier of n, hashids-style.
+// Consecutive numbers produce unrelated looking identifiers, different salts produce different identifiers.
+// This is obfuscation, not encryption: don't rely on it to keep n secret.
+func EncodeID(n int64, salt string) string {
+	u := uint64(n<<1) ^ uint64(n>>63) // zigzag, keeps small negatives short
+	base := shuffleAlphabet(publicIDAlphabet, salt)
+	lottery := base[u%uint64(len(base))]
+	alphabet := shuffleAlphabet(base, string(lottery)+salt)
+
+	var buf []byte
+	for {
+		buf = append(buf, alphabet[u%uint64(len(alphabet))])
+		u /= uint64(len(alphabet))
+		if u == 0 {
+			break
+		}
+	}
+	for i, j := 0, len(buf)-1; i < j; i, j = i+1, j-1 {
+		buf[i], buf[j] = buf[j], buf[i]
+	}
+	return string(lottery) + string(buf)
+}
+
+// DecodeID Returns number encoded by EncodeID with the same salt
+func DecodeID(id, salt string) (int64, error) {
+	if len(id) < 2 {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidID, id)
+	}
+	base := shuffleAlphabet(publicIDAlphabet, salt)
+	alphabet := shuffleAlphabet(base, id[:1]+salt)
+
+	var u uint64
+	size := uint64(len(alphabet))
+	for i := 1; i < len(id); i++ {
+		digit := strings.IndexByte(alphabet, id[i])
+		if digit < 0 || u > (math.MaxUint64-uint64(digit))/size {
+			return 0, fmt.Errorf("%w: %q", ErrInvalidID, id)
+		}
+		u = u*size + uint64(digit)
+	}
+	n := int64(u>>1) ^ -int64(u&1)
+	if EncodeID(n, salt) != id {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidID, id)
+	}
+	return n, nil
+}
+
+// shuffleAlphabet Returns alphabet permuted deterministically by salt
+func shuffleAlphabet(alphabet, salt string) string {
+	if salt == "" {
+		return alphabet
+	}
+	out := []byte(alphabet)
+	for i, v, p := len(out)-1, 0, 0; i > 0; i, v = i-1, v+1 {
+		v %= len(salt)
+		p += int(salt[v])
+		j := (int(salt[v]) + v + p) % i
+		out[i], out[j] = out[j], out[i]
+	}
+	return string(out)
+}
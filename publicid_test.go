@@ -0,0 +1,29 @@
+package tool
+
+import "math"
+
+func (s *ToolTestSuite) TestEncodeID() {
+	seen := map[string]bool{}
+	for _, n := range []int64{0, 1, 2, 3, 41, 42, 1000, -1, -1000, math.MaxInt64, math.MinInt64} {
+		id := EncodeID(n, "pepper")
+		s.Regexp(`^[a-zA-Z0-9]{2,13}$`, id)
+		s.False(seen[id], "duplicate id %s", id)
+		seen[id] = true
+
+		decoded, err := DecodeID(id, "pepper")
+		s.NoError(err, id)
+		s.Equal(n, decoded)
+	}
+
+	s.NotEqual(EncodeID(42, "pepper"), EncodeID(42, "salt"))
+	s.Equal(EncodeID(42, "pepper"), EncodeID(42, "pepper"))
+	s.NotEqual(EncodeID(1, "pepper")[1:], EncodeID(2, "pepper")[1:])
+
+	for _, id := range []string{"", "a", "a-b", "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"} {
+		_, err := DecodeID(id, "pepper")
+		s.ErrorIs(err, ErrInvalidID, id)
+	}
+	if n, err := DecodeID(EncodeID(42, "pepper"), "salt"); err == nil {
+		s.NotEqual(int64(42), n)
+	}
+}
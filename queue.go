@@ -0,0 +1,166 @@
+package tool
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/iamwavecut/tool/safetool"
+)
+
+// DefaultQueueMaxAttempts Used when Queue.MaxAttempts is not set
+const DefaultQueueMaxAttempts = 3
+
+var (
+	// ErrQueueClosed Returned by Queue.Push after Close
+	ErrQueueClosed = errors.New("queue is closed")
+	// ErrQueueNotStarted Returned by Queue.Push before Start
+	ErrQueueNotStarted = errors.New("queue is not started")
+)
+
+type (
+	// Job Queued payload with its delivery state
+	Job[T any] struct {
+		ID       uint64
+		Payload  T
+		Attempts int
+		// Err Last handler error, set for retried and dead-lettered jobs
+		Err error
+	}
+
+	// Queue In-process job queue, handlers run under Recoverer, so panics count as failed attempts.
+	// Failed jobs are retried after Backoff delay until MaxAttempts is reached, then they land in the dead-letter list.
+	// Configuration fields must be set before Start.
+	Queue[T any] struct {
+		// Workers Number of concurrent handlers, values below 1 mean 1
+		Workers int
+		// MaxAttempts Total number of handler calls per job, defaults to DefaultQueueMaxAttempts
+		MaxAttempts int
+		// Backoff Delay policy between attempts, nil means retry immediately
+		Backoff safetool.Backoff
+
+		handler func(T) error
+		jobs    chan *Job[T]
+		pending sync.WaitGroup
+		workers sync.WaitGroup
+
+		mu     sync.Mutex
+		lastID uint64
+		closed bool
+		dead   []Job[T]
+	}
+)
+
+// NewQueue Returns a queue calling handler for every pushed payload, call Start to begin processing
+func NewQueue[T any](handler func(T) error) *Queue[T] {
+	return &Queue[T]{handler: handler}
+}
+
+// Start Launches the workers, does nothing if the queue is already started or closed
+func (q *Queue[T]) Start() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.jobs != nil || q.closed {
+		return
+	}
+	workers := max(q.Workers, 1)
+	q.jobs = make(chan *Job[T], workers)
+	q.workers.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer q.workers.Done()
+			for job := range q.jobs {
+				q.process(job)
+			}
+		}()
+	}
+}
+
+// Push Enqueues the payload, blocks while all workers are busy
+func (q *Queue[T]) Push(payload T) error {
+	q.mu.Lock()
+	switch {
+	case q.closed:
+		q.mu.Unlock()
+		return ErrQueueClosed
+	case q.jobs == nil:
+		q.mu.Unlock()
+		return ErrQueueNotStarted
+	}
+	q.lastID++
+	job := &Job[T]{ID: q.lastID, Payload: payload}
+	q.pending.Add(1)
+	q.mu.Unlock()
+
+	q.jobs <- job
+	return nil
+}
+
+// Close Stops accepting new jobs and waits until queued jobs, including pending retries, are handled.
+// Closing a queue that was never started only prevents it from starting.
+func (q *Queue[T]) Close() {
+	q.mu.Lock()
+	started, closed := q.jobs != nil, q.closed
+	q.closed = true
+	q.mu.Unlock()
+	if closed || !started {
+		return
+	}
+
+	q.pending.Wait()
+	close(q.jobs)
+	q.workers.Wait()
+}
+
+// DeadLetters Returns a copy of the jobs that failed all attempts
+func (q *Queue[T]) DeadLetters() []Job[T] {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return append([]Job[T](nil), q.dead...)
+}
+
+// Redrive Pushes dead-lettered payloads back to the queue with fresh attempts, returns number of requeued jobs
+func (q *Queue[T]) Redrive() (int, error) {
+	q.mu.Lock()
+	dead := q.dead
+	q.dead = nil
+	q.mu.Unlock()
+
+	for i, job := range dead {
+		if err := q.Push(job.Payload); err != nil {
+			q.mu.Lock()
+			q.dead = append(dead[i:], q.dead...)
+			q.mu.Unlock()
+			return i, err
+		}
+	}
+	return len(dead), nil
+}
+
+func (q *Queue[T]) process(job *Job[T]) {
+	job.Attempts++
+	var err error
+	if panicErr := Recoverer(0, func() { err = q.handler(job.Payload) }, fmt.Sprintf("queue job %d", job.ID)); panicErr != nil {
+		err = panicErr
+	}
+	if err == nil {
+		q.pending.Done()
+		return
+	}
+	job.Err = err
+
+	if job.Attempts >= NonZero(q.MaxAttempts, DefaultQueueMaxAttempts) {
+		q.mu.Lock()
+		q.dead = append(q.dead, *job)
+		q.mu.Unlock()
+		q.pending.Done()
+		return
+	}
+
+	var delay time.Duration
+	if q.Backoff != nil {
+		delay = q.Backoff.Next(job.Attempts)
+	}
+	time.AfterFunc(delay, func() { q.jobs <- job })
+}
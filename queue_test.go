@@ -0,0 +1,110 @@
+package tool
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/iamwavecut/tool/safetool"
+)
+
+func (s *ToolTestSuite) TestQueue() {
+	s.Run("retry and dead letter", func() {
+		var (
+			mu       sync.Mutex
+			attempts = map[int]int{}
+			delays   []int
+		)
+		q := NewQueue(func(n int) error {
+			mu.Lock()
+			defer mu.Unlock()
+			attempts[n]++
+			switch {
+			case n == 2:
+				panic("boom")
+			case n == 3 && attempts[n] < 2:
+				return errors.New("flaky")
+			case n == 4:
+				return errors.New("permanent")
+			}
+			return nil
+		})
+		q.Workers = 2
+		q.MaxAttempts = 3
+		q.Backoff = safetool.BackoffFunc(func(attempt int) time.Duration {
+			mu.Lock()
+			delays = append(delays, attempt)
+			mu.Unlock()
+			return time.Millisecond
+		})
+		q.Start()
+		for i := 1; i <= 4; i++ {
+			s.NoError(q.Push(i))
+		}
+		q.Close()
+
+		s.Equal(map[int]int{1: 1, 2: 3, 3: 2, 4: 3}, attempts)
+		s.Len(delays, 5)
+		dead := q.DeadLetters()
+		s.Len(dead, 2)
+		for _, job := range dead {
+			s.Contains([]int{2, 4}, job.Payload)
+			s.Equal(3, job.Attempts)
+			s.Error(job.Err)
+		}
+		s.ErrorIs(q.Push(5), ErrQueueClosed)
+	})
+
+	s.Run("redrive", func() {
+		fail := true
+		var handled []string
+		q := NewQueue(func(v string) error {
+			if fail {
+				return errors.New("down")
+			}
+			handled = append(handled, v)
+			return nil
+		})
+		q.MaxAttempts = 1
+		q.Start()
+		s.NoError(q.Push("a"))
+		s.Eventually(func() bool { return len(q.DeadLetters()) == 1 }, time.Second, time.Millisecond)
+
+		fail = false
+		n, err := q.Redrive()
+		s.NoError(err)
+		s.Equal(1, n)
+		q.Close()
+		s.Equal([]string{"a"}, handled)
+		s.Empty(q.DeadLetters())
+	})
+}
+
+func (s *ToolTestSuite) TestQueueNotStarted() {
+	s.Run("push before start", func() {
+		q := NewQueue(func(int) error { return nil })
+		s.ErrorIs(q.Push(1), ErrQueueNotStarted)
+		q.Start()
+		s.NoError(q.Push(1))
+		q.Close()
+	})
+
+	s.Run("close before start", func() {
+		q := NewQueue(func(int) error { return nil })
+		s.NotPanics(q.Close)
+		q.Start()
+		s.ErrorIs(q.Push(1), ErrQueueClosed)
+		s.NotPanics(q.Close)
+	})
+}
+
+func (s *ToolTestSuite) TestQueueNegativeWorkers() {
+	var handled atomic.Int32
+	q := NewQueue(func(int) error { handled.Add(1); return nil })
+	q.Workers = -1
+	s.NotPanics(q.Start)
+	s.NoError(q.Push(1))
+	q.Close()
+	s.Equal(int32(1), handled.Load())
+}
@@ -0,0 +1,109 @@
+package tool
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrJSONPathNotFound Returned by RawJSON.Get when the path doesn't exist
+var ErrJSONPathNotFound = errors.New("json path not found")
+
+// RawJSON Encoded JSON value passed through without decoding, same as json.RawMessage.
+// Converts to and from Varchar directly: RawJSON(varchar), RawJSON.Varchar().
+type RawJSON json.RawMessage
+
+// MarshalJSON Implements json.Marshaler, nil is encoded as null
+func (r RawJSON) MarshalJSON() ([]byte, error) {
+	if r == nil {
+		return []byte("null"), nil
+	}
+	return r, nil
+}
+
+// UnmarshalJSON Implements json.Unmarshaler, keeps a copy of b
+func (r *RawJSON) UnmarshalJSON(b []byte) error {
+	if r == nil {
+		return errors.New("RawJSON: UnmarshalJSON on nil pointer")
+	}
+	*r = append((*r)[0:0], b...)
+	return nil
+}
+
+// Varchar Returns the value as Varchar
+func (r RawJSON) Varchar() Varchar {
+	return Varchar(r)
+}
+
+// String Implements fmt.Stringer
+func (r RawJSON) String() string {
+	return string(r)
+}
+
+// Valid Reports whether the value is valid JSON
+func (r RawJSON) Valid() bool {
+	return json.Valid(r)
+}
+
+// Compact Returns the value with insignificant whitespace removed
+func (r RawJSON) Compact() (RawJSON, error) {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, r); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Indent Returns the value indented, see json.Indent
+func (r RawJSON) Indent(prefix, indent string) (RawJSON, error) {
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, r, prefix, indent); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Get Returns the raw value at dotted path, array elements are addressed by index, e.g. "items.0.id".
+// Only containers along the path are decoded. Empty path returns the value itself.
+func (r RawJSON) Get(path string) (RawJSON, error) {
+	if path == "" {
+		return r, nil
+	}
+	cur := json.RawMessage(r)
+	keys := strings.Split(path, ".")
+	for i, key := range keys {
+		notFound := fmt.Errorf("%w: %s", ErrJSONPathNotFound, strings.Join(keys[:i+1], "."))
+		trimmed := bytes.TrimLeft(cur, " \t\r\n")
+		if len(trimmed) == 0 {
+			return nil, notFound
+		}
+		switch trimmed[0] {
+		case '{':
+			var obj map[string]json.RawMessage
+			if err := json.Unmarshal(cur, &obj); err != nil {
+				return nil, err
+			}
+			next, ok := obj[key]
+			if !ok {
+				return nil, notFound
+			}
+			cur = next
+		case '[':
+			var arr []json.RawMessage
+			if err := json.Unmarshal(cur, &arr); err != nil {
+				return nil, err
+			}
+			idx, err := strconv.Atoi(key)
+			if err != nil || idx < 0 || idx >= len(arr) {
+				return nil, notFound
+			}
+			cur = arr[idx]
+		default:
+			return nil, notFound
+		}
+	}
+	return RawJSON(cur), nil
+}
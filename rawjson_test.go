@@ -0,0 +1,59 @@
+package tool
+
+import (
+	"encoding/json"
+)
+
+func (s *ToolTestSuite) TestRawJSON() {
+	doc := RawJSON(Varchar(`{"items": [{"id": 1, "tags": ["a"]}, {"id": 2}], "meta": null}`))
+
+	s.Run("get", func() {
+		v, err := doc.Get("items.1.id")
+		s.NoError(err)
+		s.Equal("2", v.String())
+
+		v, err = doc.Get("items.0")
+		s.NoError(err)
+		s.JSONEq(`{"id": 1, "tags": ["a"]}`, v.String())
+
+		v, err = doc.Get("")
+		s.NoError(err)
+		s.Equal(doc, v)
+
+		for _, path := range []string{"missing", "items.2", "items.x", "items.0.id.deeper", "meta.x"} {
+			_, err = doc.Get(path)
+			s.ErrorIs(err, ErrJSONPathNotFound, path)
+		}
+		_, err = RawJSON(`{"a":`).Get("a")
+		s.Error(err)
+	})
+
+	s.Run("format", func() {
+		s.True(doc.Valid())
+		s.False(RawJSON(`{`).Valid())
+
+		compact, err := doc.Compact()
+		s.NoError(err)
+		s.Equal(`{"items":[{"id":1,"tags":["a"]},{"id":2}],"meta":null}`, compact.String())
+
+		indented, err := RawJSON(`{"a":[1]}`).Indent("", " ")
+		s.NoError(err)
+		s.Equal("{\n \"a\": [\n  1\n ]\n}", indented.Varchar().String())
+
+		_, err = RawJSON(`{`).Compact()
+		s.Error(err)
+	})
+
+	s.Run("passthrough", func() {
+		type envelope struct {
+			Kind string  `json:"kind"`
+			Data RawJSON `json:"data"`
+			Nil  RawJSON `json:"nil"`
+		}
+		var e envelope
+		s.Require().NoError(json.Unmarshal([]byte(`{"kind":"k","data":{"x": [1, 2]}}`), &e))
+		s.Equal(`{"x": [1, 2]}`, e.Data.String())
+
+		s.Equal(`{"kind":"k","data":{"x":[1,2]},"nil":null}`, Jsonify(e).String())
+	})
+}
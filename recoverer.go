@@ -0,0 +1,148 @@
+package tool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/exp/constraints"
+
+	"github.com/iamwavecut/tool/safetool"
+)
+
+type (
+	// RecoverConfig RecoverWith settings, adjusted with options
+	RecoverConfig struct {
+		// MaxPanics Number of restarts after panics, <0 restarts infinitely
+		MaxPanics int
+		// Backoff Delay policy between restarts, nil restarts immediately
+		Backoff safetool.Backoff
+		// JobID Job name used in errors and logs
+		JobID string
+		// OnPanic Called after every recovered panic with the panic value, stack and 1-based attempt number
+		OnPanic func(recovered any, stack []byte, attempt int)
+	}
+
+	// JobPanicError Recovered panic of a job, wraps *PanicError with the panic value and the stack captured at recovery,
+	// so errors.As matches both types
+	JobPanicError struct {
+		*PanicError
+		JobID   string
+		Attempt int
+		// location Function and line the panic was raised at
+		location string
+	}
+)
+
+// Error Implements error
+func (e *JobPanicError) Error() string {
+	return fmt.Sprintf(`job %s panics with message: %s, %s`, e.JobID, e.Value, e.location)
+}
+
+// Unwrap Returns the wrapped *PanicError
+func (e *JobPanicError) Unwrap() error {
+	return e.PanicError
+}
+
+// WithMaxPanics Sets number of restarts after panics, <0 restarts infinitely
+func WithMaxPanics(n int) Option[RecoverConfig] {
+	return func(c *RecoverConfig) { c.MaxPanics = n }
+}
+
+// WithRecoverBackoff Sets delay policy between restarts
+func WithRecoverBackoff(backoff safetool.Backoff) Option[RecoverConfig] {
+	return func(c *RecoverConfig) { c.Backoff = backoff }
+}
+
+// WithJobID Sets job name used in errors and logs
+func WithJobID(id string) Option[RecoverConfig] {
+	return func(c *RecoverConfig) { c.JobID = id }
+}
+
+// WithOnPanic Sets callback observing recovered panics, e.g. to report restarts to metrics
+func WithOnPanic(fn func(recovered any, stack []byte, attempt int)) Option[RecoverConfig] {
+	return func(c *RecoverConfig) { c.OnPanic = fn }
+}
+
+// RecoverWith Recovers f from panics and restarts it as options say, until ctx is done.
+// Returns nil once f returns normally, the last *JobPanicError when restarts are exhausted,
+// or ctx error joined with the last *JobPanicError.
+func RecoverWith(ctx context.Context, f func(), opts ...Option[RecoverConfig]) error {
+	cfg, err := Build(RecoverConfig{}, opts...)
+	if err != nil {
+		return err
+	}
+	var lastPanic error
+	for attempt := 1; ; attempt++ {
+		if err := ctx.Err(); err != nil {
+			if lastPanic == nil {
+				return err
+			}
+			return errors.Join(err, lastPanic)
+		}
+		panicErr := recoverPanic(f, cfg.JobID, attempt)
+		if panicErr == nil {
+			return nil
+		}
+		lastPanic = panicErr
+		if cfg.OnPanic != nil {
+			cfg.OnPanic(panicErr.Value, panicErr.Stack, attempt)
+		}
+		if cfg.MaxPanics == 0 {
+			return panicErr
+		}
+		if cfg.MaxPanics > 0 {
+			cfg.MaxPanics--
+		}
+		if cfg.Backoff == nil {
+			continue
+		}
+
+		timer := time.NewTimer(cfg.Backoff.Next(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+		case <-timer.C:
+		}
+	}
+}
+
+// RecovererCtx Recoverer that waits between restarts as backoff says and stops when ctx is done,
+// if maxPanics<0 then restarts infinitely. Returns nil once f returns normally, the last panic error
+// when restarts are exhausted, or ctx error joined with the last panic error.
+func RecovererCtx[num constraints.Integer](ctx context.Context, maxPanics num, backoff safetool.Backoff, f func(), jobID ...string) error {
+	return RecoverWith(ctx, f, WithMaxPanics(int(maxPanics)), WithRecoverBackoff(backoff), WithJobID(strings.Join(jobID, " ")))
+}
+
+// GoSafe Runs fn in a new goroutine, a panic inside is recovered and logged with the optional name as job id
+func GoSafe(fn func(), name ...string) {
+	go recoverPanic(fn, strings.Join(name, " "), 1)
+}
+
+// GoSafeDone GoSafe that returns a channel receiving nil or *JobPanicError once fn is finished, then closed
+func GoSafeDone(fn func(), name ...string) <-chan error {
+	done := make(chan error, 1)
+	go func() {
+		defer close(done)
+		if panicErr := recoverPanic(fn, strings.Join(name, " "), 1); panicErr != nil {
+			done <- panicErr
+			return
+		}
+		done <- nil
+	}()
+	return done
+}
+
+// recoverPanic Runs f, returns logged *JobPanicError describing its panic, if any
+func recoverPanic(f func(), jobID string, attempt int) (panicErr *JobPanicError) {
+	defer func() {
+		if err := recover(); err != nil {
+			panicErr = &JobPanicError{PanicError: newPanicError(err), JobID: jobID, Attempt: attempt, location: identifyPanic()}
+			tooloLog.LogError(panicErr)
+		}
+	}()
+	f()
+	return nil
+}
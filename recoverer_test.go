@@ -0,0 +1,127 @@
+package tool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/iamwavecut/tool/safetool"
+)
+
+func (s *ToolTestSuite) TestRecovererCtx() {
+	noDelay := safetool.Constant(0)
+
+	s.Run("restarts until success", func() {
+		runs := 0
+		err := RecovererCtx(context.Background(), 5, noDelay, func() {
+			runs++
+			if runs < 3 {
+				panic("boom")
+			}
+		}, "job")
+		s.NoError(err)
+		s.Equal(3, runs)
+	})
+
+	s.Run("exhausted", func() {
+		runs := 0
+		err := RecovererCtx(context.Background(), 2, noDelay, func() {
+			runs++
+			panic("boom")
+		}, "job")
+		s.ErrorContains(err, "job job panics with message: boom")
+		s.Equal(3, runs)
+	})
+
+	s.Run("cancelled during backoff", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		runs := 0
+		err := RecovererCtx(ctx, -1, safetool.Constant(time.Hour), func() {
+			runs++
+			cancel()
+			panic("boom")
+		})
+		s.ErrorIs(err, context.Canceled)
+		s.ErrorContains(err, "boom")
+		s.Equal(1, runs)
+
+		s.ErrorIs(RecovererCtx(ctx, 1, noDelay, func() { s.Fail("must not run") }), context.Canceled)
+	})
+}
+
+func (s *ToolTestSuite) TestRecoverWith() {
+	errBoom := errors.New("boom")
+	var attempts []int
+	runs := 0
+	err := RecoverWith(context.Background(), func() {
+		runs++
+		panic(errBoom)
+	}, WithMaxPanics(2), WithJobID("sync"), WithOnPanic(func(recovered any, stack []byte, attempt int) {
+		s.Equal(errBoom, recovered)
+		s.Contains(string(stack), "TestRecoverWith")
+		attempts = append(attempts, attempt)
+	}))
+	s.Equal(3, runs)
+	s.Equal([]int{1, 2, 3}, attempts)
+	s.ErrorIs(err, errBoom)
+
+	var panicErr *JobPanicError
+	s.Require().ErrorAs(err, &panicErr)
+	s.Equal("sync", panicErr.JobID)
+	s.Equal(3, panicErr.Attempt)
+	s.NotEmpty(panicErr.Stack)
+	s.Contains(panicErr.Error(), "job sync panics with message: boom")
+
+	s.NoError(RecoverWith(context.Background(), func() {}))
+}
+
+func (s *ToolTestSuite) TestGoSafe() {
+	logged := make(chan string, 1)
+	SetLogger(chanLogger(logged))
+	defer SetLogger(testLog)
+
+	GoSafe(func() { panic("boom") }, "worker")
+	s.Contains(<-logged, "job worker panics with message: boom")
+
+	s.NoError(<-GoSafeDone(func() {}))
+
+	err := <-GoSafeDone(func() { panic("boom") }, "worker")
+	var panicErr *JobPanicError
+	s.Require().ErrorAs(err, &panicErr)
+	s.Equal("worker", panicErr.JobID)
+	s.Equal("boom", panicErr.Value)
+	var pe *PanicError
+	s.Require().ErrorAs(err, &pe)
+	s.Equal("boom", pe.Value)
+
+	done := GoSafeDone(func() {})
+	<-done
+	_, open := <-done
+	s.False(open)
+}
+
+// chanLogger StdLogger sending every line to the channel
+type chanLogger chan string
+
+func (c chanLogger) Println(a ...any)          { c <- fmt.Sprintln(a...) }
+func (c chanLogger) Panicln(a ...any)          { panic(a) }
+func (c chanLogger) Printf(f string, a ...any) { c <- fmt.Sprintf(f, a...) }
+func (c chanLogger) Print(a ...any)            { c <- fmt.Sprint(a...) }
+
+func (s *ToolTestSuite) TestRecoverWithCancelNilBackoff() {
+	ctx, cancel := context.WithCancel(context.Background())
+	runs := 0
+	err := RecoverWith(ctx, func() {
+		runs++
+		cancel()
+		panic("boom")
+	}, WithMaxPanics(-1))
+	s.Equal(1, runs)
+	s.ErrorIs(err, context.Canceled)
+	var panicErr *JobPanicError
+	s.Require().ErrorAs(err, &panicErr)
+	s.Equal("boom", panicErr.Value)
+
+	s.Equal(context.Canceled, RecoverWith(ctx, func() {}))
+}
@@ -0,0 +1,75 @@
+package tool
+
+import (
+	"log/slog"
+	"os"
+	"runtime"
+	"time"
+)
+
+// processStart Time the package was initialized, the base of uptime
+var processStart = time.Now()
+
+// ResourceSnapshotData Runtime resources of the process, durations are in nanoseconds when encoded to JSON
+type ResourceSnapshotData struct {
+	Goroutines int `json:"goroutines"`
+	// HeapAlloc Bytes of allocated heap objects
+	HeapAlloc uint64 `json:"heapAlloc"`
+	// HeapSys Bytes of heap memory obtained from the OS
+	HeapSys uint64 `json:"heapSys"`
+	// TotalAlloc Cumulative bytes allocated for heap objects
+	TotalAlloc uint64 `json:"totalAlloc"`
+	// Sys Total bytes of memory obtained from the OS
+	Sys     uint64 `json:"sys"`
+	Mallocs uint64 `json:"mallocs"`
+	Frees   uint64 `json:"frees"`
+	NumGC   uint32 `json:"numGC"`
+	// LastGCPause Duration of the most recent GC stop-the-world pause
+	LastGCPause time.Duration `json:"lastGCPause"`
+	// GCPauseTotal Cumulative GC stop-the-world pause time
+	GCPauseTotal time.Duration `json:"gcPauseTotal"`
+	// OpenFDs Number of open file descriptors, -1 where /proc/self/fd is not available
+	OpenFDs int           `json:"openFDs"`
+	Uptime  time.Duration `json:"uptime"`
+}
+
+// ResourceSnapshot Returns current runtime resources of the process, reading memory stats stops the world briefly
+func ResourceSnapshot() ResourceSnapshotData {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	snapshot := ResourceSnapshotData{
+		Goroutines:   runtime.NumGoroutine(),
+		HeapAlloc:    mem.HeapAlloc,
+		HeapSys:      mem.HeapSys,
+		TotalAlloc:   mem.TotalAlloc,
+		Sys:          mem.Sys,
+		Mallocs:      mem.Mallocs,
+		Frees:        mem.Frees,
+		NumGC:        mem.NumGC,
+		GCPauseTotal: time.Duration(mem.PauseTotalNs),
+		OpenFDs:      -1,
+		Uptime:       time.Since(processStart),
+	}
+	if mem.NumGC > 0 {
+		snapshot.LastGCPause = time.Duration(mem.PauseNs[(mem.NumGC+255)%256])
+	}
+	if fds, err := os.ReadDir("/proc/self/fd"); err == nil {
+		snapshot.OpenFDs = len(fds)
+	}
+	return snapshot
+}
+
+// LogResources Logs ResourceSnapshot at info level, as a "resources" attribute with SetSlogLogger, or as JSON.
+// Call it from a ticker loop for periodic emission.
+func LogResources() {
+	if !LevelInfo.enabled() {
+		return
+	}
+	snapshot := ResourceSnapshot()
+	if tooloLog.slog != nil {
+		tooloLog.logAttrs(slog.LevelInfo, "resources", slog.Any("resources", snapshot))
+		return
+	}
+	tooloLog.LogAt(LevelInfo, "resources "+Jsonify(snapshot).String())
+}
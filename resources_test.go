@@ -0,0 +1,27 @@
+package tool
+
+import (
+	"encoding/json"
+	"runtime"
+)
+
+func (s *ToolTestSuite) TestResourceSnapshot() {
+	runtime.GC()
+	snapshot := ResourceSnapshot()
+	s.Positive(snapshot.Goroutines)
+	s.Positive(snapshot.HeapAlloc)
+	s.Positive(snapshot.NumGC)
+	s.Positive(snapshot.Uptime)
+	if runtime.GOOS == "linux" {
+		s.Positive(snapshot.OpenFDs)
+	}
+
+	var decoded map[string]any
+	s.NoError(json.Unmarshal(Jsonify(snapshot).Bytes(), &decoded))
+	s.Contains(decoded, "goroutines")
+	s.Contains(decoded, "openFDs")
+
+	testLog.buf = ""
+	LogResources()
+	s.Contains(testLog.buf, `resources {"goroutines":`)
+}
@@ -0,0 +1,54 @@
+package tool
+
+import (
+	"context"
+	"time"
+
+	"github.com/iamwavecut/tool/safetool"
+)
+
+// RetryConfig Retry settings, adjusted with options
+type RetryConfig struct {
+	// Retries Number of retries after the first failed call, <0 retries until ctx is done
+	Retries int
+	// Backoff Delay policy between attempts, nil retries without delay
+	Backoff safetool.Backoff
+	// Retryable Reports whether the error is worth retrying, nil retries every error
+	Retryable func(err error) bool
+}
+
+// DefaultRetryConfig Used as the base of Retry options
+var DefaultRetryConfig = RetryConfig{
+	Retries: 3,
+	Backoff: safetool.ExponentialWithJitter(100*time.Millisecond, 10*time.Second, 0.2),
+}
+
+// WithMaxAttempts Limits the total number of calls to n, the first one included, n<1 retries until ctx is done
+func WithMaxAttempts(n int) Option[RetryConfig] {
+	return func(c *RetryConfig) { c.Retries = If(n < 1, -1, n-1) }
+}
+
+// WithMaxRetries Limits the number of calls after the first failed one to n, n<0 retries until ctx is done
+func WithMaxRetries(n int) Option[RetryConfig] {
+	return func(c *RetryConfig) { c.Retries = n }
+}
+
+// WithRetryBackoff Sets delay policy between attempts
+func WithRetryBackoff(backoff safetool.Backoff) Option[RetryConfig] {
+	return func(c *RetryConfig) { c.Backoff = backoff }
+}
+
+// WithRetryIf Retries only errors for which retryable returns true, others are returned immediately
+func WithRetryIf(retryable func(err error) bool) Option[RetryConfig] {
+	return func(c *RetryConfig) { c.Retryable = retryable }
+}
+
+// Retry Calls f until it succeeds, the attempts are exhausted or ctx is done, returning the last error.
+// Options are applied over DefaultRetryConfig.
+func Retry(ctx context.Context, f func(ctx context.Context) error, opts ...Option[RetryConfig]) error {
+	cfg, err := Build(DefaultRetryConfig, opts...)
+	if err != nil {
+		return err
+	}
+	return safetool.RetryIf(ctx, cfg.Retries, cfg.Backoff, f, cfg.Retryable)
+}
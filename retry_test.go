@@ -0,0 +1,69 @@
+package tool
+
+import (
+	"context"
+	"errors"
+
+	"github.com/iamwavecut/tool/safetool"
+)
+
+func (s *ToolTestSuite) TestRetry() {
+	errBoom := errors.New("boom")
+	calls := 0
+	failing := func(context.Context) error {
+		calls++
+		return errBoom
+	}
+	noDelay := WithRetryBackoff(safetool.Constant(0))
+
+	for _, tc := range []struct {
+		opt   Option[RetryConfig]
+		calls int
+	}{
+		{WithMaxAttempts(5), 5},
+		{WithMaxAttempts(1), 1},
+		{WithMaxRetries(5), 6},
+		{WithMaxRetries(0), 1},
+		{nil, DefaultRetryConfig.Retries + 1},
+	} {
+		calls = 0
+		s.ErrorIs(Retry(context.Background(), failing, noDelay, tc.opt), errBoom)
+		s.Equal(tc.calls, calls)
+	}
+
+	calls = 0
+	s.NoError(Retry(context.Background(), func(context.Context) error {
+		calls++
+		return If(calls < 3, errBoom, nil)
+	}, noDelay, WithMaxAttempts(3)))
+	s.Equal(3, calls)
+
+	calls = 0
+	errFatal := errors.New("fatal")
+	s.ErrorIs(Retry(context.Background(), func(context.Context) error {
+		calls++
+		return errFatal
+	}, noDelay, WithRetryIf(func(err error) bool { return !errors.Is(err, errFatal) })), errFatal)
+	s.Equal(1, calls)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	calls = 0
+	s.ErrorIs(Retry(ctx, func(context.Context) error {
+		calls++
+		if calls == 10 {
+			cancel()
+		}
+		return errBoom
+	}, noDelay, WithMaxAttempts(0)), context.Canceled)
+	s.Equal(10, calls)
+}
+
+func (s *ToolTestSuite) TestRetryNilBackoff() {
+	calls := 0
+	err := Retry(context.Background(), func(context.Context) error {
+		calls++
+		return errors.New("boom")
+	}, WithRetryBackoff(nil), WithMaxAttempts(3))
+	s.Error(err)
+	s.Equal(3, calls)
+}
@@ -0,0 +1,125 @@
+package safetool
+
+import (
+	"context"
+	"crypto/rand"
+	"math"
+	"math/big"
+	"time"
+)
+
+type (
+	// Backoff Retry delay policy
+	Backoff interface {
+		// Next Returns delay before the next attempt, attempt starts from 1 for the first retry
+		Next(attempt int) time.Duration
+	}
+
+	// BackoffFunc Adapts a function to Backoff
+	BackoffFunc func(attempt int) time.Duration
+)
+
+// Next Implements Backoff
+func (f BackoffFunc) Next(attempt int) time.Duration {
+	return f(attempt)
+}
+
+// Constant Returns Backoff with the same delay for every attempt
+func Constant(d time.Duration) Backoff {
+	return BackoffFunc(func(int) time.Duration { return d })
+}
+
+// Exponential Returns Backoff doubling the base delay on every attempt, capped at max if max > 0
+func Exponential(base, max time.Duration) Backoff {
+	return BackoffFunc(func(attempt int) time.Duration {
+		if attempt < 1 {
+			attempt = 1
+		}
+		d := float64(base) * math.Pow(2, float64(attempt-1))
+		switch {
+		case max > 0 && d > float64(max):
+			return max
+		case d >= math.MaxInt64:
+			return math.MaxInt64
+		}
+		return time.Duration(d)
+	})
+}
+
+// ExponentialWithJitter Returns Exponential backoff with every delay randomized by ± fraction
+func ExponentialWithJitter(base, max time.Duration, fraction float64) Backoff {
+	exp := Exponential(base, max)
+	return BackoffFunc(func(attempt int) time.Duration {
+		d := exp.Next(attempt)
+		jittered, err := Jitter(d, fraction)
+		if err != nil {
+			return d
+		}
+		return jittered
+	})
+}
+
+// Jitter Returns a random duration in range d ± d*fraction using crypto/rand, fraction is clamped to [0, 1].
+// The spread is capped so that the range fits int64, results above math.MaxInt64 saturate.
+func Jitter(d time.Duration, fraction float64) (time.Duration, error) {
+	const maxSpread = (math.MaxInt64 - 1) / 2
+	if fraction > 1 {
+		fraction = 1
+	}
+	var spread int64
+	if f := float64(d) * fraction; f >= maxSpread {
+		spread = maxSpread
+	} else {
+		spread = int64(f)
+	}
+	if spread <= 0 {
+		return d, nil
+	}
+	bInt, err := rand.Int(rand.Reader, big.NewInt(2*spread+1))
+	if err != nil {
+		return d, err
+	}
+	low, offset := int64(d)-spread, bInt.Int64()
+	if offset > math.MaxInt64-low {
+		return math.MaxInt64, nil
+	}
+	return time.Duration(low + offset), nil
+}
+
+// RetryFuncWithBackoff Re-runs function if error returned, waiting between attempts as backoff says.
+// attempts<0 retries until success or ctx is done, ctx cancellation stops waiting immediately.
+func RetryFuncWithBackoff(ctx context.Context, attempts int, backoff Backoff, f func(ctx context.Context) error) error {
+	return RetryIf(ctx, attempts, backoff, f, nil)
+}
+
+// RetryIf RetryFuncWithBackoff that retries only errors for which retryable returns true,
+// other errors are returned immediately, nil retryable retries every error, nil backoff retries without delay
+func RetryIf(ctx context.Context, attempts int, backoff Backoff, f func(ctx context.Context) error, retryable func(err error) bool) error {
+	var retryErr error
+	for attempt := 1; ; attempt++ {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxDone(ctxErr, retryErr)
+		}
+		if retryErr = f(ctx); retryErr == nil {
+			return nil
+		}
+		if attempts == 0 || retryable != nil && !retryable(retryErr) {
+			return retryErr
+		}
+		if attempts > 0 {
+			attempts--
+		}
+
+		var delay time.Duration
+		if backoff != nil {
+			delay = backoff.Next(attempt)
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctxDone(ctx.Err(), retryErr)
+		case <-timer.C:
+		}
+	}
+}
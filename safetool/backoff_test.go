@@ -0,0 +1,105 @@
+package safetool
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+)
+
+func (s *SafeToolTestSuite) TestBackoff() {
+	s.Run("constant", func() {
+		b := Constant(time.Second)
+		s.Equal(time.Second, b.Next(1))
+		s.Equal(time.Second, b.Next(10))
+	})
+
+	s.Run("exponential", func() {
+		b := Exponential(100*time.Millisecond, time.Second)
+		s.Equal(100*time.Millisecond, b.Next(0))
+		s.Equal(100*time.Millisecond, b.Next(1))
+		s.Equal(200*time.Millisecond, b.Next(2))
+		s.Equal(800*time.Millisecond, b.Next(4))
+		s.Equal(time.Second, b.Next(5))
+		s.Equal(time.Second, b.Next(1000))
+		s.Greater(Exponential(time.Second, 0).Next(1000), time.Duration(0))
+	})
+
+	s.Run("exponential with jitter", func() {
+		b := ExponentialWithJitter(time.Second, time.Minute, 0.5)
+		for i := 0; i < 50; i++ {
+			d := b.Next(2)
+			s.GreaterOrEqual(d, time.Second)
+			s.LessOrEqual(d, 3*time.Second)
+		}
+	})
+}
+
+func (s *SafeToolTestSuite) TestJitter() {
+	for i := 0; i < 50; i++ {
+		d, err := Jitter(time.Second, 0.1)
+		s.NoError(err)
+		s.GreaterOrEqual(d, 900*time.Millisecond)
+		s.LessOrEqual(d, 1100*time.Millisecond)
+	}
+	d, err := Jitter(time.Second, 0)
+	s.NoError(err)
+	s.Equal(time.Second, d)
+
+	s.Run("huge", func() {
+		b := ExponentialWithJitter(time.Second, 0, 0.6)
+		for i := 0; i < 50; i++ {
+			s.NotPanics(func() { s.Greater(b.Next(100), time.Duration(0)) })
+			d, err := Jitter(math.MaxInt64, 1)
+			s.NoError(err)
+			s.GreaterOrEqual(d, time.Duration(0))
+		}
+	})
+}
+
+func (s *SafeToolTestSuite) TestRetryFuncWithBackoff() {
+	var attempts []int
+	calls := 0
+	err := RetryFuncWithBackoff(context.Background(), 3, BackoffFunc(func(attempt int) time.Duration {
+		attempts = append(attempts, attempt)
+		return 0
+	}), func(context.Context) error {
+		calls++
+		return errors.New("down")
+	})
+	s.EqualError(err, "down")
+	s.Equal(4, calls)
+	s.Equal([]int{1, 2, 3}, attempts)
+}
+
+func (s *SafeToolTestSuite) TestRetryIf() {
+	errInvalid := errors.New("invalid input")
+	errTemporary := errors.New("temporary")
+	retryable := func(err error) bool { return !errors.Is(err, errInvalid) }
+
+	s.Run("permanent error", func() {
+		calls := 0
+		err := RetryIf(context.Background(), 5, Constant(0), func(context.Context) error {
+			calls++
+			if calls == 2 {
+				return errInvalid
+			}
+			return errTemporary
+		}, retryable)
+		s.ErrorIs(err, errInvalid)
+		s.Equal(2, calls)
+	})
+
+	s.Run("retryable until success", func() {
+		calls := 0
+		err := RetryIf(context.Background(), 5, Constant(0), func(context.Context) error {
+			calls++
+			if calls < 3 {
+				return errTemporary
+			}
+			return nil
+		}, retryable)
+		s.NoError(err)
+		s.Equal(3, calls)
+	})
+}
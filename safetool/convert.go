@@ -0,0 +1,385 @@
+package safetool
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"sync"
+)
+
+// ConvertTag Struct tag of source fields naming the destination field, "-" skips the field
+const ConvertTag = "convert"
+
+// maxConvertDepth Limits nesting of converted values, guards against pointer cycles
+const maxConvertDepth = 64
+
+// ErrNotConvertible Returned when a value can't be converted to the destination type
+var ErrNotConvertible = errors.New("not convertible")
+
+// tagError Conversion failure of a field addressed by ConvertTag, never skipped silently
+type tagError struct {
+	error
+}
+
+func (e *tagError) Unwrap() error { return e.error }
+
+// ConvertConfig Adjusts conversion rules of ConvertStructWith, the zero value applies ConvertStruct rules
+type ConvertConfig struct {
+	// StringCoercion Converts between strings and bools or numbers with strconv instead of failing, integers
+	// are formatted as numbers rather than converted to rune strings
+	StringCoercion bool
+	// IntBase Base of integers formatted and parsed with StringCoercion, 0 means 10
+	IntBase int
+	// FloatFormat Format of floats as in strconv.FormatFloat, 0 means 'g'
+	FloatFormat byte
+	// FloatPrecision Precision of formatted floats as in strconv.FormatFloat, used when FloatFormat is set
+	FloatPrecision int
+}
+
+var (
+	convertersMu sync.RWMutex
+	converters   = map[typePair]reflect.Value{}
+)
+
+// RegisterConverter Registers fn converting S to D, used by ConvertStruct and ConvertSlice in place of built-in rules
+// for this exact type pair, pointers to S included. Meant to be called from init, panics if the pair is already registered.
+// Errors returned by fn fail the whole conversion, even for fields matched by name.
+func RegisterConverter[S, D any](fn func(S) (D, error)) {
+	key := typePair{reflect.TypeOf((*S)(nil)).Elem(), reflect.TypeOf((*D)(nil)).Elem()}
+	convertersMu.Lock()
+	defer convertersMu.Unlock()
+	if _, ok := converters[key]; ok {
+		panic(fmt.Sprintf("converter %s to %s is already registered", key.src, key.dst))
+	}
+	converters[key] = reflect.ValueOf(fn)
+	convertPlans.Range(func(key, _ any) bool {
+		convertPlans.Delete(key)
+		return true
+	})
+}
+
+// ConvertMap Returns src with keys and values converted with ConvertStruct rules,
+// fails if any entry is not convertible or converted keys collide
+func ConvertMap[K comparable, V any, K2 comparable, V2 any](src map[K]V) (map[K2]V2, error) {
+	return ConvertStruct[map[K]V, map[K2]V2](src)
+}
+
+// ConvertStruct Returns src converted to D.
+// Values are assigned or converted as is when Go allows it, pointers are dereferenced and allocated as needed,
+// slices and maps are converted element-wise. Structs are copied field by field: exported fields are matched by name
+// or by the `convert:"TargetName"` tag of the source field, nested structs are converted recursively.
+// Fields matched by name but having incompatible types are skipped, while tagged ones result in error.
+// Bools and numbers are not formatted to strings or parsed back unless ConvertConfig.StringCoercion is set,
+// integers convert to strings as Go conversion does, to the rune string.
+func ConvertStruct[S, D any](src S) (D, error) {
+	return ConvertStructWith[S, D](src, ConvertConfig{})
+}
+
+// ConvertStructWith Returns src converted to D with ConvertStruct rules adjusted by cfg
+func ConvertStructWith[S, D any](src S, cfg ConvertConfig) (D, error) {
+	var dst D
+	c := converter{cfg}
+	val, err := c.value(reflect.ValueOf(&src).Elem(), reflect.TypeOf(&dst).Elem(), 0)
+	if err != nil {
+		return dst, err
+	}
+	reflect.ValueOf(&dst).Elem().Set(val)
+	return dst, nil
+}
+
+type (
+	convertMode int
+
+	// convertPlan Precompiled conversion between a type pair, cached in convertPlans
+	convertPlan struct {
+		mode   convertMode
+		fields []fieldPlan
+		// custom Registered converter func
+		custom reflect.Value
+		// err Set for struct pairs having tagged fields without target
+		err error
+	}
+
+	fieldPlan struct {
+		name     string
+		src      int
+		dst      []int
+		dstType  reflect.Type
+		tagged   bool
+		embedded bool
+	}
+
+	typePair struct {
+		src, dst reflect.Type
+	}
+)
+
+const (
+	convertFail convertMode = iota
+	convertAssign
+	convertConvert
+	convertDeref
+	convertAlloc
+	convertStructFields
+	convertSlice
+	convertMapEntries
+	convertStrconv
+	convertCustom
+)
+
+// convertPlans Caches *convertPlan by typePair, so repeated conversions skip type inspection
+var convertPlans sync.Map
+
+func planFor(srcType, dstType reflect.Type) *convertPlan {
+	key := typePair{srcType, dstType}
+	if plan, ok := convertPlans.Load(key); ok {
+		return plan.(*convertPlan)
+	}
+	plan, _ := convertPlans.LoadOrStore(key, newConvertPlan(srcType, dstType))
+	return plan.(*convertPlan)
+}
+
+func newConvertPlan(srcType, dstType reflect.Type) *convertPlan {
+	convertersMu.RLock()
+	custom, ok := converters[typePair{srcType, dstType}]
+	convertersMu.RUnlock()
+	if ok {
+		return &convertPlan{mode: convertCustom, custom: custom}
+	}
+
+	srcKind, dstKind := srcType.Kind(), dstType.Kind()
+	switch {
+	case srcType.AssignableTo(dstType):
+		return &convertPlan{mode: convertAssign}
+	case isStrconvPair(srcKind, dstKind):
+		return &convertPlan{mode: convertStrconv}
+	case srcType.ConvertibleTo(dstType) && !(srcKind == reflect.Slice && (dstKind == reflect.Ptr || dstKind == reflect.Array)):
+		// slice to array conversions panic on short slices
+		return &convertPlan{mode: convertConvert}
+	case srcKind == reflect.Ptr || srcKind == reflect.Interface:
+		return &convertPlan{mode: convertDeref}
+	case dstKind == reflect.Ptr:
+		return &convertPlan{mode: convertAlloc}
+	case srcKind == reflect.Struct && dstKind == reflect.Struct:
+		return newStructPlan(srcType, dstType)
+	case (srcKind == reflect.Slice || srcKind == reflect.Array) && dstKind == reflect.Slice:
+		return &convertPlan{mode: convertSlice}
+	case srcKind == reflect.Map && dstKind == reflect.Map:
+		return &convertPlan{mode: convertMapEntries}
+	}
+	return &convertPlan{mode: convertFail}
+}
+
+func newStructPlan(srcType, dstType reflect.Type) *convertPlan {
+	plan := &convertPlan{mode: convertStructFields}
+	for i := 0; i < srcType.NumField(); i++ {
+		field := srcType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, tagged := field.Name, false
+		if tag, ok := field.Tag.Lookup(ConvertTag); ok {
+			if tag == "-" {
+				continue
+			}
+			name, tagged = tag, true
+		}
+
+		dstField, ok := dstType.FieldByName(name)
+		if !ok || !dstField.IsExported() {
+			if tagged && plan.err == nil {
+				plan.err = &tagError{fmt.Errorf("%w: field %s has no target %s.%s", ErrNotConvertible, field.Name, dstType, name)}
+			}
+			continue
+		}
+		plan.fields = append(plan.fields, fieldPlan{
+			name:     field.Name,
+			src:      i,
+			dst:      dstField.Index,
+			dstType:  dstField.Type,
+			tagged:   tagged,
+			embedded: len(dstField.Index) > 1,
+		})
+	}
+	return plan
+}
+
+// converter Converts values by cached plans, applying its config
+type converter struct {
+	cfg ConvertConfig
+}
+
+func (c converter) value(src reflect.Value, dstType reflect.Type, depth int) (reflect.Value, error) {
+	if depth > maxConvertDepth {
+		return reflect.Value{}, fmt.Errorf("%w: nesting deeper than %d", ErrNotConvertible, maxConvertDepth)
+	}
+	if !src.IsValid() {
+		return reflect.Zero(dstType), nil
+	}
+
+	plan := planFor(src.Type(), dstType)
+	switch plan.mode {
+	case convertAssign:
+		return src, nil
+	case convertConvert:
+		return src.Convert(dstType), nil
+	case convertDeref:
+		if src.IsNil() {
+			return reflect.Zero(dstType), nil
+		}
+		return c.value(src.Elem(), dstType, depth+1)
+	case convertAlloc:
+		val, err := c.value(src, dstType.Elem(), depth+1)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		ptr := reflect.New(dstType.Elem())
+		ptr.Elem().Set(val)
+		return ptr, nil
+	case convertStructFields:
+		return c.structFields(src, dstType, plan, depth)
+	case convertSlice:
+		if src.Kind() == reflect.Slice && src.IsNil() {
+			return reflect.Zero(dstType), nil
+		}
+		dst := reflect.MakeSlice(dstType, src.Len(), src.Len())
+		for i := 0; i < src.Len(); i++ {
+			val, err := c.value(src.Index(i), dstType.Elem(), depth+1)
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("index %d: %w", i, err)
+			}
+			dst.Index(i).Set(val)
+		}
+		return dst, nil
+	case convertMapEntries:
+		return c.mapEntries(src, dstType, depth)
+	case convertStrconv:
+		if c.cfg.StringCoercion {
+			return c.coerce(src, dstType)
+		}
+		if src.Type().ConvertibleTo(dstType) {
+			return src.Convert(dstType), nil
+		}
+	case convertCustom:
+		out := plan.custom.Call([]reflect.Value{src})
+		if err, _ := out[1].Interface().(error); err != nil {
+			return reflect.Value{}, &tagError{fmt.Errorf("%s to %s: %w", src.Type(), dstType, err)}
+		}
+		return out[0], nil
+	}
+	return reflect.Value{}, fmt.Errorf("%w: %s to %s", ErrNotConvertible, src.Type(), dstType)
+}
+
+func (c converter) mapEntries(src reflect.Value, dstType reflect.Type, depth int) (reflect.Value, error) {
+	if src.IsNil() {
+		return reflect.Zero(dstType), nil
+	}
+	dst := reflect.MakeMapWithSize(dstType, src.Len())
+	iter := src.MapRange()
+	for iter.Next() {
+		key, err := c.value(iter.Key(), dstType.Key(), depth+1)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("key %v: %w", iter.Key(), err)
+		}
+		if dst.MapIndex(key).IsValid() {
+			return reflect.Value{}, fmt.Errorf("%w: key %v collides with another key as %v", ErrNotConvertible, iter.Key(), key)
+		}
+		val, err := c.value(iter.Value(), dstType.Elem(), depth+1)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("key %v: %w", iter.Key(), err)
+		}
+		dst.SetMapIndex(key, val)
+	}
+	return dst, nil
+}
+
+func (c converter) structFields(src reflect.Value, dstType reflect.Type, plan *convertPlan, depth int) (reflect.Value, error) {
+	if plan.err != nil {
+		return reflect.Value{}, plan.err
+	}
+	dst := reflect.New(dstType).Elem()
+	for _, field := range plan.fields {
+		val, err := c.value(src.Field(field.src), field.dstType, depth+1)
+		if err != nil {
+			var tagErr *tagError
+			if field.tagged || errors.As(err, &tagErr) {
+				return reflect.Value{}, &tagError{fmt.Errorf("field %s: %w", field.name, err)}
+			}
+			continue
+		}
+		if !field.embedded {
+			dst.Field(field.dst[0]).Set(val)
+			continue
+		}
+		target, err := dst.FieldByIndexErr(field.dst)
+		if err != nil {
+			continue // promoted through nil embedded pointer
+		}
+		target.Set(val)
+	}
+	return dst, nil
+}
+
+// isStrconvPair Reports whether one kind is string and the other is bool or number
+func isStrconvPair(src, dst reflect.Kind) bool {
+	isScalar := func(k reflect.Kind) bool {
+		return k == reflect.Bool || k >= reflect.Int && k <= reflect.Uintptr || k == reflect.Float32 || k == reflect.Float64
+	}
+	return src == reflect.String && isScalar(dst) || isScalar(src) && dst == reflect.String
+}
+
+func (c converter) coerce(src reflect.Value, dstType reflect.Type) (reflect.Value, error) {
+	base := c.cfg.IntBase
+	if base == 0 {
+		base = 10
+	}
+	dst := reflect.New(dstType).Elem()
+	if dstType.Kind() == reflect.String {
+		var s string
+		switch src.Kind() {
+		case reflect.Bool:
+			s = strconv.FormatBool(src.Bool())
+		case reflect.Float32, reflect.Float64:
+			format, prec := c.cfg.FloatFormat, c.cfg.FloatPrecision
+			if format == 0 {
+				format, prec = 'g', -1
+			}
+			s = strconv.FormatFloat(src.Float(), format, prec, src.Type().Bits())
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			s = strconv.FormatInt(src.Int(), base)
+		default:
+			s = strconv.FormatUint(src.Uint(), base)
+		}
+		dst.SetString(s)
+		return dst, nil
+	}
+
+	var err error
+	switch s := src.String(); dstType.Kind() {
+	case reflect.Bool:
+		var b bool
+		if b, err = strconv.ParseBool(s); err == nil {
+			dst.SetBool(b)
+		}
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		if f, err = strconv.ParseFloat(s, dstType.Bits()); err == nil {
+			dst.SetFloat(f)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var i int64
+		if i, err = strconv.ParseInt(s, base, dstType.Bits()); err == nil {
+			dst.SetInt(i)
+		}
+	default:
+		var u uint64
+		if u, err = strconv.ParseUint(s, base, dstType.Bits()); err == nil {
+			dst.SetUint(u)
+		}
+	}
+	if err != nil {
+		return reflect.Value{}, fmt.Errorf("%w: %w", ErrNotConvertible, err)
+	}
+	return dst, nil
+}
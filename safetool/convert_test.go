@@ -0,0 +1,244 @@
+package safetool
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type (
+	convertAddress struct {
+		City string
+		Zip  int
+	}
+
+	convertUser struct {
+		Name     string
+		Age      int
+		Email    string `convert:"Contact"`
+		Password string `convert:"-"`
+		Address  *convertAddress
+		Tags     []string
+		Friends  []convertAddress
+		Score    string
+		internal int
+	}
+
+	convertAddressDTO struct {
+		City string
+		Zip  int64
+	}
+
+	convertUserDTO struct {
+		Name     string
+		Age      *int
+		Contact  string
+		Password string
+		Address  convertAddressDTO
+		Tags     []string
+		Friends  []*convertAddressDTO
+		Score    int
+	}
+)
+
+type convertCelsius struct{ Degrees float64 }
+
+func init() {
+	RegisterConverter(func(t time.Time) (string, error) { return t.Format(time.RFC3339), nil })
+	RegisterConverter(func(s string) (convertCelsius, error) {
+		if !strings.HasSuffix(s, "C") {
+			return convertCelsius{}, errors.New("no unit")
+		}
+		var c convertCelsius
+		_, err := fmt.Sscanf(s, "%gC", &c.Degrees)
+		return c, err
+	})
+}
+
+func (s *SafeToolTestSuite) TestConvertStruct() {
+	s.Run("struct", func() {
+		src := convertUser{
+			Name:     "a",
+			Age:      30,
+			Email:    "a@b.c",
+			Password: "secret",
+			Address:  &convertAddress{City: "X", Zip: 1},
+			Tags:     []string{"t"},
+			Friends:  []convertAddress{{City: "Y"}},
+			Score:    "high",
+			internal: 1,
+		}
+		dst, err := ConvertStruct[convertUser, convertUserDTO](src)
+		s.NoError(err)
+		age := 30
+		s.Equal(convertUserDTO{
+			Name:    "a",
+			Age:     &age,
+			Contact: "a@b.c",
+			Address: convertAddressDTO{City: "X", Zip: 1},
+			Tags:    []string{"t"},
+			Friends: []*convertAddressDTO{{City: "Y"}},
+		}, dst)
+	})
+
+	s.Run("pointers", func() {
+		dst, err := ConvertStruct[*convertAddress, convertAddressDTO](&convertAddress{City: "Z"})
+		s.NoError(err)
+		s.Equal(convertAddressDTO{City: "Z"}, dst)
+
+		ptr, err := ConvertStruct[*convertAddress, *convertAddressDTO](nil)
+		s.NoError(err)
+		s.Nil(ptr)
+
+		n, err := ConvertStruct[*int, float64](nil)
+		s.NoError(err)
+		s.Zero(n)
+	})
+
+	s.Run("errors", func() {
+		_, err := ConvertStruct[string, convertAddress]("x")
+		s.ErrorIs(err, ErrNotConvertible)
+
+		type badTag struct {
+			City []int `convert:"City"`
+		}
+		_, err = ConvertStruct[badTag, convertAddress](badTag{})
+		s.ErrorIs(err, ErrNotConvertible)
+		s.ErrorContains(err, "field City")
+
+		type missingTag struct {
+			City string `convert:"Town"`
+		}
+		_, err = ConvertStruct[missingTag, convertAddress](missingTag{})
+		s.ErrorContains(err, "no target")
+
+		type nested struct {
+			Inner badTag
+		}
+		type nestedDTO struct {
+			Inner convertAddress
+		}
+		_, err = ConvertStruct[nested, nestedDTO](nested{})
+		s.ErrorContains(err, "field Inner: field City")
+
+		_, err = ConvertStruct[[]int, [2]int]([]int{1})
+		s.ErrorIs(err, ErrNotConvertible)
+	})
+}
+
+func (s *SafeToolTestSuite) TestConvertMap() {
+	s.Run("structs", func() {
+		dst, err := ConvertMap[string, convertAddress, string, convertAddressDTO](map[string]convertAddress{
+			"home": {City: "X", Zip: 1},
+		})
+		s.NoError(err)
+		s.Equal(map[string]convertAddressDTO{"home": {City: "X", Zip: 1}}, dst)
+	})
+
+	s.Run("keys and nil", func() {
+		dst, err := ConvertMap[int, *int, int64, float64](map[int]*int{1: nil, 2: ptrTo(3)})
+		s.NoError(err)
+		s.Equal(map[int64]float64{1: 0, 2: 3}, dst)
+
+		empty, err := ConvertMap[int, int, int, int](nil)
+		s.NoError(err)
+		s.Nil(empty)
+	})
+
+	s.Run("errors", func() {
+		_, err := ConvertMap[string, string, string, convertAddress](map[string]string{"a": "x"})
+		s.ErrorIs(err, ErrNotConvertible)
+		s.ErrorContains(err, "key a")
+
+		_, err = ConvertMap[float64, int, int, int](map[float64]int{1.2: 1, 1.7: 2})
+		s.ErrorIs(err, ErrNotConvertible)
+		s.ErrorContains(err, "collides")
+	})
+}
+
+func (s *SafeToolTestSuite) TestConvertStructWith() {
+	type src struct {
+		Count   int
+		Enabled string
+		Ratio   float32 `convert:"Label"`
+		Port    string
+	}
+	type dst struct {
+		Count   string
+		Enabled bool
+		Label   string
+		Port    uint16
+	}
+	in := src{Count: 7, Enabled: "true", Ratio: 0.5, Port: "8080"}
+
+	_, err := ConvertStruct[src, dst](in)
+	s.ErrorIs(err, ErrNotConvertible)
+	s.ErrorContains(err, "field Ratio")
+
+	runes, err := ConvertStruct[[]int, []string]([]int{65, 0x4e16})
+	s.NoError(err)
+	s.Equal([]string{"A", "世"}, runes)
+
+	out, err := ConvertStructWith[src, dst](in, ConvertConfig{StringCoercion: true})
+	s.NoError(err)
+	s.Equal(dst{Count: "7", Enabled: true, Label: "0.5", Port: 8080}, out)
+
+	out, err = ConvertStructWith[src, dst](src{Port: "70000"}, ConvertConfig{StringCoercion: true})
+	s.NoError(err)
+	s.Zero(out.Port, "overflowing field matched by name is skipped")
+
+	_, err = ConvertStructWith[[]string, []int]([]string{"1", "x"}, ConvertConfig{StringCoercion: true})
+	s.ErrorIs(err, ErrNotConvertible)
+	s.ErrorContains(err, "index 1")
+}
+
+func (s *SafeToolTestSuite) TestRegisterConverter() {
+	type event struct {
+		At   *time.Time
+		Temp string
+	}
+	type eventDTO struct {
+		At   string
+		Temp convertCelsius
+	}
+	at := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	out, err := ConvertStruct[[]event, []eventDTO]([]event{{At: &at, Temp: "21.5C"}, {Temp: "-3C"}})
+	s.NoError(err)
+	s.Equal([]eventDTO{{At: "2024-05-01T12:00:00Z", Temp: convertCelsius{21.5}}, {Temp: convertCelsius{-3}}}, out)
+
+	_, err = ConvertStruct[event, eventDTO](event{Temp: "hot"})
+	s.ErrorContains(err, "field Temp: string to safetool.convertCelsius: no unit")
+
+	s.Panics(func() { RegisterConverter(func(t time.Time) (string, error) { return "", nil }) })
+}
+
+func (s *SafeToolTestSuite) TestConvertPlanCache() {
+	src := []convertAddress{{City: "X", Zip: 1}, {City: "Y", Zip: 2}}
+	dst, err := ConvertStruct[[]convertAddress, []convertAddressDTO](src)
+	s.NoError(err)
+	s.Equal([]convertAddressDTO{{City: "X", Zip: 1}, {City: "Y", Zip: 2}}, dst)
+
+	plan, ok := convertPlans.Load(typePair{reflect.TypeOf(convertAddress{}), reflect.TypeOf(convertAddressDTO{})})
+	s.Require().True(ok)
+	s.Len(plan.(*convertPlan).fields, 2)
+	s.Same(plan, planFor(reflect.TypeOf(convertAddress{}), reflect.TypeOf(convertAddressDTO{})))
+}
+
+func BenchmarkConvertStruct(b *testing.B) {
+	src := make([]convertUser, 1000)
+	for i := range src {
+		src[i] = convertUser{Name: "a", Age: i, Email: "a@b.c", Address: &convertAddress{City: "X", Zip: i}, Tags: []string{"t"}}
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := ConvertStruct[[]convertUser, []convertUserDTO](src); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func ptrTo[T any](v T) *T { return &v }
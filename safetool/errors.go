@@ -0,0 +1,81 @@
+package safetool
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// MultiError Aggregates several errors, errors.Is and errors.As match any of them
+type MultiError struct {
+	Errors []error
+}
+
+// Error Returns messages of all errors, one per line
+func (e *MultiError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Unwrap Returns the aggregated errors
+func (e *MultiError) Unwrap() []error {
+	return e.Errors
+}
+
+// Is Reports whether any of the aggregated errors matches target
+func (e *MultiError) Is(target error) bool {
+	for _, err := range e.Errors {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As Finds the first aggregated error that matches target
+func (e *MultiError) As(target any) bool {
+	for _, err := range e.Errors {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// CombineErrors Returns nil if there are no non-nil errors, the error itself if there is only one,
+// and *MultiError otherwise
+func CombineErrors(errs ...error) error {
+	var nonNil []error
+	for _, err := range errs {
+		if err != nil {
+			nonNil = append(nonNil, err)
+		}
+	}
+	switch len(nonNil) {
+	case 0:
+		return nil
+	case 1:
+		return nonNil[0]
+	}
+	return &MultiError{Errors: nonNil}
+}
+
+// Wrap Returns err annotated with fmt.Sprintf(msg, args...) as "msg: err", keeping it for errors.Is and errors.As.
+// Returns nil for nil err.
+func Wrap(err error, msg string, args ...any) error {
+	if err == nil {
+		return nil
+	}
+	return fmt.Errorf("%s: %w", fmt.Sprintf(msg, args...), err)
+}
+
+// WrapIf Wrap that annotates only errors pred reports true for, others are returned as is
+func WrapIf(err error, pred func(err error) bool, msg string, args ...any) error {
+	if err == nil || !pred(err) {
+		return err
+	}
+	return Wrap(err, msg, args...)
+}
@@ -0,0 +1,47 @@
+package safetool
+
+import (
+	"errors"
+	"io/fs"
+)
+
+func (s *SafeToolTestSuite) TestCombineErrors() {
+	s.Run("none", func() {
+		s.NoError(CombineErrors())
+		s.NoError(CombineErrors(nil, nil))
+	})
+
+	s.Run("single", func() {
+		err := errors.New("one")
+		s.Same(err, CombineErrors(nil, err))
+	})
+
+	s.Run("multiple", func() {
+		sentinel := errors.New("sentinel")
+		pathErr := &fs.PathError{Op: "open", Path: "x", Err: fs.ErrNotExist}
+		err := CombineErrors(sentinel, nil, pathErr)
+
+		var multi *MultiError
+		s.Require().ErrorAs(err, &multi)
+		s.Len(multi.Errors, 2)
+		s.EqualError(err, "sentinel\nopen x: file does not exist")
+		s.ErrorIs(err, sentinel)
+		s.ErrorIs(err, fs.ErrNotExist)
+
+		var target *fs.PathError
+		s.Require().ErrorAs(err, &target)
+		s.Equal("x", target.Path)
+	})
+}
+
+func (s *SafeToolTestSuite) TestWrap() {
+	s.NoError(Wrap(nil, "reading %s", "config"))
+	err := Wrap(fs.ErrNotExist, "reading %s", "config")
+	s.EqualError(err, "reading config: file does not exist")
+	s.ErrorIs(err, fs.ErrNotExist)
+
+	isNotExist := func(err error) bool { return errors.Is(err, fs.ErrNotExist) }
+	s.NoError(WrapIf(nil, isNotExist, "reading"))
+	s.EqualError(WrapIf(fs.ErrNotExist, isNotExist, "reading"), "reading: file does not exist")
+	s.Equal(fs.ErrPermission, WrapIf(fs.ErrPermission, isNotExist, "reading"))
+}
@@ -0,0 +1,64 @@
+package safetool
+
+import (
+	"golang.org/x/exp/constraints"
+	"golang.org/x/exp/slices"
+)
+
+// Entry Key-value pair of a map
+type Entry[K comparable, V any] struct {
+	Key   K
+	Value V
+}
+
+// Keys Returns keys of the map in unspecified order
+func Keys[K comparable, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// SortedKeys Returns keys of the map in ascending order
+func SortedKeys[K constraints.Ordered, V any](m map[K]V) []K {
+	keys := Keys(m)
+	slices.Sort(keys)
+	return keys
+}
+
+// Values Returns values of the map in unspecified order
+func Values[K comparable, V any](m map[K]V) []V {
+	values := make([]V, 0, len(m))
+	for _, value := range m {
+		values = append(values, value)
+	}
+	return values
+}
+
+// Entries Returns entries of the map in unspecified order
+func Entries[K comparable, V any](m map[K]V) []Entry[K, V] {
+	entries := make([]Entry[K, V], 0, len(m))
+	for key, value := range m {
+		entries = append(entries, Entry[K, V]{Key: key, Value: value})
+	}
+	return entries
+}
+
+// SortedEntries Returns entries of the map in ascending key order
+func SortedEntries[K constraints.Ordered, V any](m map[K]V) []Entry[K, V] {
+	entries := make([]Entry[K, V], 0, len(m))
+	for _, key := range SortedKeys(m) {
+		entries = append(entries, Entry[K, V]{Key: key, Value: m[key]})
+	}
+	return entries
+}
+
+// FromEntries Returns map of the entries, later entries win on duplicate keys
+func FromEntries[K comparable, V any](entries []Entry[K, V]) map[K]V {
+	m := make(map[K]V, len(entries))
+	for _, entry := range entries {
+		m[entry.Key] = entry.Value
+	}
+	return m
+}
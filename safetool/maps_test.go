@@ -0,0 +1,22 @@
+package safetool
+
+import "golang.org/x/exp/slices"
+
+func (s *SafeToolTestSuite) TestMaps() {
+	m := map[string]int{"b": 2, "a": 1, "c": 3}
+
+	keys := Keys(m)
+	slices.Sort(keys)
+	s.Equal([]string{"a", "b", "c"}, keys)
+	s.Equal([]string{"a", "b", "c"}, SortedKeys(m))
+	values := Values(m)
+	slices.Sort(values)
+	s.Equal([]int{1, 2, 3}, values)
+	s.Empty(Keys[int, int](nil))
+
+	sorted := SortedEntries(m)
+	s.Equal([]Entry[string, int]{{"a", 1}, {"b", 2}, {"c", 3}}, sorted)
+	s.ElementsMatch(sorted, Entries(m))
+	s.Equal(m, FromEntries(Entries(m)))
+	s.Equal(map[string]int{"a": 2}, FromEntries([]Entry[string, int]{{"a", 1}, {"a", 2}}))
+}
@@ -0,0 +1,78 @@
+package safetool
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// ParallelMap Calls fn for every item using up to concurrency goroutines, returns results in the input order.
+// By default stops dispatching items on the first error and returns it, with collectAll it processes every item
+// and returns all errors as *MultiError, even a single one. Errors are annotated with the item index.
+// Returns ctx error if ctx is done before all items are dispatched.
+func ParallelMap[T, R any](ctx context.Context, items []T, concurrency int, fn func(T) (R, error), collectAll ...bool) ([]R, error) {
+	all := len(collectAll) > 0 && collectAll[0]
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		results = make([]R, len(items))
+		errs    = make([]error, len(items))
+		sem     = make(chan struct{}, concurrency)
+		wg      sync.WaitGroup
+		once    sync.Once
+		first   error
+		ctxErr  error
+	)
+
+dispatch:
+	for i := range items {
+		select {
+		case <-ctx.Done():
+			ctxErr = ctx.Err()
+			break dispatch
+		case sem <- struct{}{}:
+		}
+		if ctxErr = ctx.Err(); ctxErr != nil {
+			<-sem
+			break
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			res, err := fn(items[i])
+			if err != nil {
+				errs[i] = fmt.Errorf("item %d: %w", i, err)
+				if !all {
+					once.Do(func() {
+						first = errs[i]
+						cancel()
+					})
+				}
+				return
+			}
+			results[i] = res
+		}(i)
+	}
+	wg.Wait()
+
+	if first != nil {
+		return results, first
+	}
+	var failed []error
+	for _, err := range errs {
+		if err != nil {
+			failed = append(failed, err)
+		}
+	}
+	if len(failed) > 0 {
+		return results, &MultiError{Errors: failed}
+	}
+	return results, ctxErr
+}
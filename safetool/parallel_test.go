@@ -0,0 +1,81 @@
+package safetool
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+func (s *SafeToolTestSuite) TestParallelMap() {
+	s.Run("order and concurrency", func() {
+		var running, peak int32
+		items := []int{5, 4, 3, 2, 1, 0}
+		res, err := ParallelMap(context.Background(), items, 2, func(n int) (string, error) {
+			cur := atomic.AddInt32(&running, 1)
+			defer atomic.AddInt32(&running, -1)
+			for {
+				old := atomic.LoadInt32(&peak)
+				if cur <= old || atomic.CompareAndSwapInt32(&peak, old, cur) {
+					break
+				}
+			}
+			time.Sleep(time.Duration(n) * time.Millisecond)
+			return strconv.Itoa(n), nil
+		})
+		s.NoError(err)
+		s.Equal([]string{"5", "4", "3", "2", "1", "0"}, res)
+		s.LessOrEqual(peak, int32(2))
+	})
+
+	s.Run("first error", func() {
+		var calls int32
+		_, err := ParallelMap(context.Background(), []int{1, 2, 3, 4, 5}, 1, func(n int) (int, error) {
+			atomic.AddInt32(&calls, 1)
+			if n == 2 {
+				return 0, errors.New("bad")
+			}
+			return n, nil
+		})
+		s.EqualError(err, "item 1: bad")
+		s.Less(calls, int32(5))
+	})
+
+	s.Run("collect all", func() {
+		sentinel := errors.New("odd")
+		res, err := ParallelMap(context.Background(), []int{1, 2, 3, 4}, 4, func(n int) (int, error) {
+			if n%2 == 1 {
+				return 0, sentinel
+			}
+			return n * 10, nil
+		}, true)
+		s.Equal([]int{0, 20, 0, 40}, res)
+		var multi *MultiError
+		s.Require().ErrorAs(err, &multi)
+		s.Len(multi.Errors, 2)
+		s.ErrorIs(err, sentinel)
+		s.EqualError(err, "item 0: odd\nitem 2: odd")
+	})
+
+	s.Run("collect single", func() {
+		_, err := ParallelMap(context.Background(), []int{1, 2}, 2, func(n int) (int, error) {
+			if n == 2 {
+				return 0, errors.New("bad")
+			}
+			return n, nil
+		}, true)
+		var multi *MultiError
+		s.Require().ErrorAs(err, &multi)
+		s.Len(multi.Errors, 1)
+		s.EqualError(err, "item 1: bad")
+	})
+
+	s.Run("context", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		res, err := ParallelMap(ctx, []int{1, 2}, 2, func(n int) (int, error) { return n, nil })
+		s.ErrorIs(err, context.Canceled)
+		s.Len(res, 2)
+	})
+}
@@ -0,0 +1,158 @@
+package safetool
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// ErrNoChoice Returned by random choice helpers when there is nothing to choose from
+var ErrNoChoice = errors.New("nothing to choose from")
+
+// UUIDv4 Returns random UUID from crypto/rand, e.g. "f47ac10b-58cc-4372-a567-0e02b2c3d479"
+func UUIDv4() (string, error) {
+	var u [16]byte
+	if _, err := rand.Read(u[:]); err != nil {
+		return "", err
+	}
+	return formatUUID(u, 4), nil
+}
+
+// UUIDv7 Returns time-ordered UUID of the current time, see UUIDv7At
+func UUIDv7() (string, error) {
+	return UUIDv7At(time.Now())
+}
+
+// UUIDv7At Returns UUID starting with Unix milliseconds of t followed by crypto/rand bits,
+// so UUIDs of different milliseconds sort by time both as strings and bytes
+func UUIDv7At(t time.Time) (string, error) {
+	var u [16]byte
+	if _, err := rand.Read(u[6:]); err != nil {
+		return "", err
+	}
+	var ms [8]byte
+	binary.BigEndian.PutUint64(ms[:], uint64(t.UnixMilli()))
+	copy(u[:6], ms[2:])
+	return formatUUID(u, 7), nil
+}
+
+// formatUUID Sets version and RFC 9562 variant bits, returns canonical form
+func formatUUID(u [16]byte, version byte) string {
+	u[6] = u[6]&0x0f | version<<4
+	u[8] = u[8]&0x3f | 0x80
+	var buf [36]byte
+	hex.Encode(buf[0:8], u[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], u[10:])
+	return string(buf[:])
+}
+
+// RandInt64 Returns uniform random number in [min, max) using crypto/rand, any int64 range is supported
+func RandInt64(min, max int64) (int64, error) {
+	if max <= min {
+		return 0, fmt.Errorf("empty range [%d, %d)", min, max)
+	}
+	n, err := randRange(big.NewInt(min), big.NewInt(max))
+	if err != nil {
+		return 0, err
+	}
+	return n.Int64(), nil
+}
+
+// RandUint64 Returns uniform random number in [min, max) using crypto/rand, any uint64 range is supported
+func RandUint64(min, max uint64) (uint64, error) {
+	if max <= min {
+		return 0, fmt.Errorf("empty range [%d, %d)", min, max)
+	}
+	n, err := randRange(new(big.Int).SetUint64(min), new(big.Int).SetUint64(max))
+	if err != nil {
+		return 0, err
+	}
+	return n.Uint64(), nil
+}
+
+// RandDuration Returns uniform random duration in [min, max) using crypto/rand
+func RandDuration(min, max time.Duration) (time.Duration, error) {
+	d, err := RandInt64(int64(min), int64(max))
+	return time.Duration(d), err
+}
+
+// randRange Returns uniform random number in [min, max)
+func randRange(min, max *big.Int) (*big.Int, error) {
+	n, err := rand.Int(rand.Reader, new(big.Int).Sub(max, min))
+	if err != nil {
+		return nil, err
+	}
+	return n.Add(n, min), nil
+}
+
+// RandChoice Returns uniformly chosen item using crypto/rand
+func RandChoice[T any](items ...T) (T, error) {
+	var zero T
+	if len(items) == 0 {
+		return zero, ErrNoChoice
+	}
+	i, err := randIndex(int64(len(items)))
+	if err != nil {
+		return zero, err
+	}
+	return items[i], nil
+}
+
+// WeightedChoice Returns item chosen with probability proportional to its weight using crypto/rand,
+// items with zero weight are never chosen
+func WeightedChoice[T any](items []T, weights []uint) (T, error) {
+	var zero T
+	if len(items) != len(weights) {
+		return zero, fmt.Errorf("%d items but %d weights", len(items), len(weights))
+	}
+	total := new(big.Int)
+	for _, w := range weights {
+		total.Add(total, new(big.Int).SetUint64(uint64(w)))
+	}
+	if total.Sign() == 0 {
+		return zero, ErrNoChoice
+	}
+	pick, err := rand.Int(rand.Reader, total)
+	if err != nil {
+		return zero, err
+	}
+	for i, w := range weights {
+		pick.Sub(pick, new(big.Int).SetUint64(uint64(w)))
+		if pick.Sign() < 0 {
+			return items[i], nil
+		}
+	}
+	panic("unreachable")
+}
+
+// Shuffle Shuffles items in place using crypto/rand, all permutations are equally likely
+func Shuffle[T any](items []T) error {
+	for i := len(items) - 1; i > 0; i-- {
+		j, err := randIndex(int64(i + 1))
+		if err != nil {
+			return err
+		}
+		items[i], items[j] = items[j], items[i]
+	}
+	return nil
+}
+
+// randIndex Returns uniform random number in [0, n)
+func randIndex(n int64) (int64, error) {
+	i, err := rand.Int(rand.Reader, big.NewInt(n))
+	if err != nil {
+		return 0, err
+	}
+	return i.Int64(), nil
+}
@@ -0,0 +1,82 @@
+package safetool
+
+import (
+	"math"
+	"strings"
+	"time"
+)
+
+func (s *SafeToolTestSuite) TestUUID() {
+	const pattern = `^[0-9a-f]{8}-[0-9a-f]{4}-%s[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`
+
+	v4a, err := UUIDv4()
+	s.NoError(err)
+	v4b, _ := UUIDv4()
+	s.Regexp(strings.Replace(pattern, "%s", "4", 1), v4a)
+	s.NotEqual(v4a, v4b)
+
+	t := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	v7, err := UUIDv7At(t)
+	s.NoError(err)
+	s.Regexp(strings.Replace(pattern, "%s", "7", 1), v7)
+	s.Equal("018f3406-9e00-7", v7[:15], "starts with Unix milliseconds")
+
+	later, _ := UUIDv7At(t.Add(time.Millisecond))
+	s.Less(v7, later)
+	now, err := UUIDv7()
+	s.NoError(err)
+	s.Less(later, now)
+}
+
+func (s *SafeToolTestSuite) TestRandChoice() {
+	_, err := RandChoice[int]()
+	s.ErrorIs(err, ErrNoChoice)
+
+	seen := map[string]int{}
+	for i := 0; i < 300; i++ {
+		v, err := RandChoice("a", "b", "c")
+		s.NoError(err)
+		seen[v]++
+	}
+	s.Len(seen, 3)
+
+	seen = map[string]int{}
+	for i := 0; i < 1000; i++ {
+		v, err := WeightedChoice([]string{"never", "rare", "often"}, []uint{0, 1, 9})
+		s.NoError(err)
+		seen[v]++
+	}
+	s.Zero(seen["never"])
+	s.Greater(seen["often"], seen["rare"])
+
+	_, err = WeightedChoice([]string{"a"}, []uint{0})
+	s.ErrorIs(err, ErrNoChoice)
+	_, err = WeightedChoice([]string{"a"}, nil)
+	s.Error(err)
+
+	items := []int{1, 2, 3, 4, 5, 6, 7, 8}
+	s.NoError(Shuffle(items))
+	s.ElementsMatch([]int{1, 2, 3, 4, 5, 6, 7, 8}, items)
+	s.NoError(Shuffle([]int(nil)))
+}
+
+func (s *SafeToolTestSuite) TestRandRange() {
+	for i := 0; i < 100; i++ {
+		n, err := RandInt64(math.MinInt64, math.MinInt64+2)
+		s.NoError(err)
+		s.True(n == math.MinInt64 || n == math.MinInt64+1)
+	}
+	_, err := RandInt64(math.MinInt64, math.MaxInt64)
+	s.NoError(err)
+	u, err := RandUint64(math.MaxUint64-1, math.MaxUint64)
+	s.NoError(err)
+	s.Equal(uint64(math.MaxUint64-1), u)
+	_, err = RandUint64(5, 5)
+	s.Error(err)
+
+	d, err := RandDuration(-time.Second, time.Second)
+	s.NoError(err)
+	s.True(d >= -time.Second && d < time.Second)
+	_, err = RandDuration(time.Second, 0)
+	s.Error(err)
+}
@@ -0,0 +1,81 @@
+package safetool
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrInvalidRate Returned by NewRateLimiter for non-positive rate
+var ErrInvalidRate = errors.New("rate must be positive")
+
+// RateLimiter In-process token bucket rate limiter, safe for concurrent use
+type RateLimiter struct {
+	mu      sync.Mutex
+	rate    float64
+	burst   float64
+	tokens  float64
+	updated time.Time
+}
+
+// NewRateLimiter Returns a limiter allowing rate events per second with optional burst, defaults to 1.
+// The bucket starts full.
+func NewRateLimiter(rate float64, burst ...int) (*RateLimiter, error) {
+	if rate <= 0 {
+		return nil, ErrInvalidRate
+	}
+	b := 1
+	if len(burst) > 0 && burst[0] > 0 {
+		b = burst[0]
+	}
+	return &RateLimiter{rate: rate, burst: float64(b), tokens: float64(b), updated: time.Now()}, nil
+}
+
+// Allow Takes a token if one is available right now
+func (l *RateLimiter) Allow() bool {
+	return l.take() == 0
+}
+
+// Wait Blocks until a token is taken or ctx is done
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	for {
+		wait := l.take()
+		if wait == 0 {
+			return nil
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// take Takes a token and returns 0, or returns time until the next token is available
+func (l *RateLimiter) take() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.tokens = min(l.burst, l.tokens+now.Sub(l.updated).Seconds()*l.rate)
+	l.updated = now
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+	return max(time.Duration((1-l.tokens)/l.rate*float64(time.Second)), 1)
+}
+
+// RetryFuncRateLimited RetryFuncWithBackoff that waits for limiter before every attempt, including the first one,
+// so retries of many callers sharing the limiter can't flood the remote side on failure storms
+func RetryFuncRateLimited(ctx context.Context, attempts int, backoff Backoff, limiter *RateLimiter, f func(ctx context.Context) error) error {
+	return RetryFuncWithBackoff(ctx, attempts, backoff, func(ctx context.Context) error {
+		if err := limiter.Wait(ctx); err != nil {
+			return err
+		}
+		return f(ctx)
+	})
+}
@@ -0,0 +1,53 @@
+package safetool
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+func (s *SafeToolTestSuite) TestRateLimiter() {
+	_, err := NewRateLimiter(0)
+	s.ErrorIs(err, ErrInvalidRate)
+
+	s.Run("allow", func() {
+		l, err := NewRateLimiter(10, 3)
+		s.Require().NoError(err)
+		s.True(l.Allow())
+		s.True(l.Allow())
+		s.True(l.Allow())
+		s.False(l.Allow())
+		time.Sleep(120 * time.Millisecond)
+		s.True(l.Allow())
+		s.False(l.Allow())
+	})
+
+	s.Run("wait", func() {
+		l, err := NewRateLimiter(50)
+		s.Require().NoError(err)
+		start := time.Now()
+		for i := 0; i < 4; i++ {
+			s.NoError(l.Wait(context.Background()))
+		}
+		s.GreaterOrEqual(time.Since(start), 50*time.Millisecond)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+		defer cancel()
+		s.ErrorIs(l.Wait(ctx), context.DeadlineExceeded)
+	})
+
+	s.Run("retry", func() {
+		l, err := NewRateLimiter(50)
+		s.Require().NoError(err)
+		errBoom := errors.New("boom")
+		calls := 0
+		start := time.Now()
+		err = RetryFuncRateLimited(context.Background(), 3, Constant(0), l, func(context.Context) error {
+			calls++
+			return errBoom
+		})
+		s.ErrorIs(err, errBoom)
+		s.Equal(4, calls)
+		s.GreaterOrEqual(time.Since(start), 50*time.Millisecond)
+	})
+}
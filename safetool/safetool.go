@@ -0,0 +1,22 @@
+// Package safetool Non-panicking, context-aware counterparts of tool helpers
+package safetool
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// RetryFuncCtx Re-runs function if error returned, attempts<0 retries until success or ctx is done.
+// Stops as soon as ctx is done, returning ctx error annotated with the last function error.
+func RetryFuncCtx(ctx context.Context, attempts int, sleep time.Duration, f func(ctx context.Context) error) error {
+	return RetryFuncWithBackoff(ctx, attempts, Constant(sleep), f)
+}
+
+// ctxDone Returns ctx error annotated with the last error, if any
+func ctxDone(ctxErr, lastErr error) error {
+	if lastErr == nil {
+		return ctxErr
+	}
+	return fmt.Errorf("%w (last error: %s)", ctxErr, lastErr)
+}
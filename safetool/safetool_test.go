@@ -0,0 +1,66 @@
+package safetool
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type SafeToolTestSuite struct {
+	suite.Suite
+}
+
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(SafeToolTestSuite))
+}
+
+func (s *SafeToolTestSuite) TestRetryFuncCtx() {
+	s.Run("success", func() {
+		calls := 0
+		err := RetryFuncCtx(context.Background(), 5, 0, func(context.Context) error {
+			calls++
+			if calls < 3 {
+				return errors.New("not yet")
+			}
+			return nil
+		})
+		s.NoError(err)
+		s.Equal(3, calls)
+	})
+
+	s.Run("attempts exhausted", func() {
+		calls := 0
+		err := RetryFuncCtx(context.Background(), 2, 0, func(context.Context) error {
+			calls++
+			return errors.New("always")
+		})
+		s.EqualError(err, "always")
+		s.Equal(3, calls)
+	})
+
+	s.Run("infinite stopped by context", func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		calls := 0
+		err := RetryFuncCtx(ctx, -1, time.Millisecond, func(context.Context) error {
+			calls++
+			return errors.New("down")
+		})
+		s.ErrorIs(err, context.DeadlineExceeded)
+		s.ErrorContains(err, "last error: down")
+		s.Greater(calls, 1)
+	})
+
+	s.Run("canceled before start", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err := RetryFuncCtx(ctx, 3, 0, func(context.Context) error {
+			s.Fail("must not be called")
+			return nil
+		})
+		s.ErrorIs(err, context.Canceled)
+	})
+}
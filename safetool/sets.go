@@ -0,0 +1,60 @@
+package safetool
+
+// Unique Returns new slice of the first occurrences of every value, in the input order
+func Unique[T comparable](s []T) []T {
+	if s == nil {
+		return nil
+	}
+	return appendUnique(make([]T, 0, len(s)), make(map[T]struct{}, len(s)), s)
+}
+
+// Intersect Returns unique values of a also present in b, in the order of a
+func Intersect[T comparable](a, b []T) []T {
+	return filterSet(a, b, true)
+}
+
+// Difference Returns unique values of a not present in b, in the order of a
+func Difference[T comparable](a, b []T) []T {
+	return filterSet(a, b, false)
+}
+
+// Union Returns unique values of all slices, in the order of their first occurrence
+func Union[T comparable](slices ...[]T) []T {
+	n := 0
+	for _, s := range slices {
+		n += len(s)
+	}
+	out, seen := make([]T, 0, n), make(map[T]struct{}, n)
+	for _, s := range slices {
+		out = appendUnique(out, seen, s)
+	}
+	return out
+}
+
+func filterSet[T comparable](a, b []T, present bool) []T {
+	set := make(map[T]struct{}, len(b))
+	for _, v := range b {
+		set[v] = struct{}{}
+	}
+	out, seen := make([]T, 0, len(a)), make(map[T]struct{}, len(a))
+	for _, v := range a {
+		if _, ok := set[v]; ok != present {
+			continue
+		}
+		if _, ok := seen[v]; !ok {
+			seen[v] = struct{}{}
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func appendUnique[T comparable](out []T, seen map[T]struct{}, s []T) []T {
+	for _, v := range s {
+		if _, ok := seen[v]; !ok {
+			seen[v] = struct{}{}
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,14 @@
+package safetool
+
+func (s *SafeToolTestSuite) TestSets() {
+	s.Equal([]int{3, 1, 2}, Unique([]int{3, 1, 3, 2, 1}))
+	s.Nil(Unique[int](nil))
+
+	a, b := []int{5, 1, 2, 1, 3}, []int{3, 4, 1}
+	s.Equal([]int{1, 3}, Intersect(a, b))
+	s.Equal([]int{5, 2}, Difference(a, b))
+	s.Equal([]int{5, 1, 2, 3, 4}, Union(a, b))
+	s.Empty(Intersect(a, nil))
+	s.Equal([]int{5, 1, 2, 3}, Difference(a, nil))
+	s.Empty(Union[string]())
+}
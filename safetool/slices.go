@@ -0,0 +1,84 @@
+package safetool
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidChunkSize Returned by Chunk and Batch for sizes less than 1
+var ErrInvalidChunkSize = errors.New("chunk size must be positive")
+
+// MapSlice Returns results of fn for every item, stops on the first error annotated with the item index
+func MapSlice[T, R any](items []T, fn func(T) (R, error)) ([]R, error) {
+	if items == nil {
+		return nil, nil
+	}
+	out := make([]R, len(items))
+	for i, item := range items {
+		res, err := fn(item)
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %w", i, err)
+		}
+		out[i] = res
+	}
+	return out, nil
+}
+
+// FilterSlice Returns items fn reported true for, in the input order, stops on the first error annotated with the item index
+func FilterSlice[T any](items []T, fn func(T) (bool, error)) ([]T, error) {
+	if items == nil {
+		return nil, nil
+	}
+	out := make([]T, 0, len(items))
+	for i, item := range items {
+		keep, err := fn(item)
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %w", i, err)
+		}
+		if keep {
+			out = append(out, item)
+		}
+	}
+	return out, nil
+}
+
+// ReduceSlice Folds items into acc with fn, stops on the first error annotated with the item index
+func ReduceSlice[T, R any](items []T, acc R, fn func(R, T) (R, error)) (R, error) {
+	for i, item := range items {
+		next, err := fn(acc, item)
+		if err != nil {
+			return acc, fmt.Errorf("item %d: %w", i, err)
+		}
+		acc = next
+	}
+	return acc, nil
+}
+
+// Chunk Splits s into consecutive chunks of size, the last one may be shorter.
+// Chunks share the backing array of s, but are capped so appending to one doesn't overwrite the next.
+func Chunk[T any](s []T, size int) ([][]T, error) {
+	if size < 1 {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidChunkSize, size)
+	}
+	chunks := make([][]T, 0, (len(s)+size-1)/size)
+	err := Batch(s, size, func(chunk []T) error {
+		chunks = append(chunks, chunk)
+		return nil
+	})
+	return chunks, err
+}
+
+// Batch Calls fn with consecutive chunks of s of size in order, stops on the first error annotated with the chunk offset.
+// Chunks share the backing array of s the same way as in Chunk.
+func Batch[T any](s []T, size int, fn func([]T) error) error {
+	if size < 1 {
+		return fmt.Errorf("%w: %d", ErrInvalidChunkSize, size)
+	}
+	for i := 0; i < len(s); i += size {
+		end := min(i+size, len(s))
+		if err := fn(s[i:end:end]); err != nil {
+			return fmt.Errorf("batch at %d: %w", i, err)
+		}
+	}
+	return nil
+}
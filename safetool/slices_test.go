@@ -0,0 +1,79 @@
+package safetool
+
+import (
+	"errors"
+	"strconv"
+)
+
+func (s *SafeToolTestSuite) TestSliceHelpers() {
+	errOdd := errors.New("odd")
+
+	s.Run("map", func() {
+		res, err := MapSlice([]string{"1", "2"}, strconv.Atoi)
+		s.NoError(err)
+		s.Equal([]int{1, 2}, res)
+
+		_, err = MapSlice([]string{"1", "x"}, strconv.Atoi)
+		s.ErrorContains(err, "item 1")
+		res, err = MapSlice(nil, strconv.Atoi)
+		s.NoError(err)
+		s.Nil(res)
+	})
+
+	s.Run("filter", func() {
+		res, err := FilterSlice([]int{1, 2, 3, 4}, func(n int) (bool, error) { return n%2 == 0, nil })
+		s.NoError(err)
+		s.Equal([]int{2, 4}, res)
+
+		_, err = FilterSlice([]int{2, 3}, func(n int) (bool, error) {
+			if n%2 == 1 {
+				return false, errOdd
+			}
+			return true, nil
+		})
+		s.ErrorIs(err, errOdd)
+		s.ErrorContains(err, "item 1")
+	})
+
+	s.Run("reduce", func() {
+		sum, err := ReduceSlice([]int{1, 2, 3}, "", func(acc string, n int) (string, error) { return acc + strconv.Itoa(n), nil })
+		s.NoError(err)
+		s.Equal("123", sum)
+
+		acc, err := ReduceSlice([]int{2, 3, 4}, 0, func(acc, n int) (int, error) {
+			if n%2 == 1 {
+				return 0, errOdd
+			}
+			return acc + n, nil
+		})
+		s.ErrorIs(err, errOdd)
+		s.Equal(2, acc)
+	})
+}
+
+func (s *SafeToolTestSuite) TestChunk() {
+	chunks, err := Chunk([]int{1, 2, 3, 4, 5}, 2)
+	s.NoError(err)
+	s.Equal([][]int{{1, 2}, {3, 4}, {5}}, chunks)
+	_ = append(chunks[0], 9)
+	s.Equal([]int{3, 4}, chunks[1], "appending to a chunk keeps the next intact")
+
+	chunks, err = Chunk([]int(nil), 3)
+	s.NoError(err)
+	s.Empty(chunks)
+	_, err = Chunk([]int{1}, 0)
+	s.ErrorIs(err, ErrInvalidChunkSize)
+
+	var sizes []int
+	errFull := errors.New("full")
+	err = Batch([]string{"a", "b", "c", "d", "e"}, 2, func(batch []string) error {
+		sizes = append(sizes, len(batch))
+		if len(sizes) == 2 {
+			return errFull
+		}
+		return nil
+	})
+	s.ErrorIs(err, errFull)
+	s.ErrorContains(err, "batch at 2")
+	s.Equal([]int{2, 2}, sizes)
+}
@@ -0,0 +1,51 @@
+package safetool
+
+import (
+	"reflect"
+	"sync"
+)
+
+// First Returns the first value pred reports true for, and whether there was one
+func First[T any](pred func(T) bool, vals ...T) (T, bool) {
+	for _, v := range vals {
+		if pred(v) {
+			return v, true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// NonZeroFunc Returns the first non-zero value or zero value if all values are zero.
+// Unlike tool.NonZero works with non-comparable types: slices, maps, funcs and structs containing them.
+// Empty but non-nil slices and maps are not zero.
+func NonZeroFunc[T any](vals ...T) T {
+	v, _ := First(func(v T) bool { return !isZero(v) }, vals...)
+	return v
+}
+
+// IsZeroAny Checks if v is nil or the zero value of its dynamic type, structs containing slices or maps included.
+// Empty but non-nil slices and maps are not zero.
+func IsZeroAny(v any) bool {
+	return v == nil || reflect.ValueOf(v).IsZero()
+}
+
+func isZero[T any](v T) bool {
+	return reflect.ValueOf(&v).Elem().IsZero()
+}
+
+// LazyValue Value computed on the first Get, see Lazy
+type LazyValue[T any] struct {
+	get func() (T, error)
+}
+
+// Lazy Returns handle calling init once on the first Get, the value and the error are cached for later calls.
+// If init panics, every Get panics with the same value.
+func Lazy[T any](init func() (T, error)) *LazyValue[T] {
+	return &LazyValue[T]{get: sync.OnceValues(init)}
+}
+
+// Get Returns the value and the error of init, calling it on the first use
+func (l *LazyValue[T]) Get() (T, error) {
+	return l.get()
+}
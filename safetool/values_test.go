@@ -0,0 +1,61 @@
+package safetool
+
+import "errors"
+
+func (s *SafeToolTestSuite) TestFirst() {
+	v, ok := First(func(n int) bool { return n > 2 }, 1, 3, 5)
+	s.True(ok)
+	s.Equal(3, v)
+	v, ok = First(func(n int) bool { return n > 9 }, 1, 3)
+	s.False(ok)
+	s.Zero(v)
+}
+
+func (s *SafeToolTestSuite) TestNonZeroFunc() {
+	s.Equal([]int{}, NonZeroFunc(nil, []int{}, []int{1}))
+	s.Equal(map[string]int{"a": 1}, NonZeroFunc(nil, map[string]int{"a": 1}))
+	s.Nil(NonZeroFunc[[]int](nil, nil))
+	s.Nil(NonZeroFunc[[]int]())
+
+	type config struct {
+		Hosts []string
+	}
+	s.Equal(config{Hosts: []string{"a"}}, NonZeroFunc(config{}, config{Hosts: []string{"a"}}))
+	s.NotNil(NonZeroFunc(nil, func() {}))
+}
+
+func (s *SafeToolTestSuite) TestIsZeroAny() {
+	s.True(IsZeroAny(nil))
+	s.True(IsZeroAny(0))
+	s.True(IsZeroAny(map[string]int(nil)))
+	s.False(IsZeroAny(map[string]int{}))
+	s.True(IsZeroAny(struct{ Tags []string }{}))
+	s.False(IsZeroAny(struct{ Tags []string }{Tags: []string{"a"}}))
+	s.True(IsZeroAny((*int)(nil)))
+}
+
+func (s *SafeToolTestSuite) TestLazy() {
+	calls := 0
+	lazy := Lazy(func() (int, error) {
+		calls++
+		return 42, nil
+	})
+	s.Zero(calls)
+	for i := 0; i < 3; i++ {
+		v, err := lazy.Get()
+		s.NoError(err)
+		s.Equal(42, v)
+	}
+	s.Equal(1, calls)
+
+	errBoom := errors.New("boom")
+	failing := Lazy(func() (string, error) {
+		calls++
+		return "", errBoom
+	})
+	_, err := failing.Get()
+	s.ErrorIs(err, errBoom)
+	_, err = failing.Get()
+	s.ErrorIs(err, errBoom)
+	s.Equal(2, calls)
+}
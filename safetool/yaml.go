@@ -0,0 +1,34 @@
+package safetool
+
+import (
+	"bytes"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Yamlify Returns YAML serialization of the value, indented with two spaces.
+// Unsupported values, e.g. channels or functions, result in error instead of the yaml package panic.
+func Yamlify(v any) (out string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			out, err = "", fmt.Errorf("yaml: %v", r)
+		}
+	}()
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(v); err != nil {
+		return "", err
+	}
+	if err := enc.Close(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// Unyamlify Unmarshalls YAML value to the target pointer value
+func Unyamlify[T ~[]byte | ~string](in T, target any) error {
+	return yaml.Unmarshal([]byte(in), target)
+}
@@ -0,0 +1,29 @@
+package safetool
+
+type yamlConfig struct {
+	Name  string            `yaml:"name"`
+	Ports []int             `yaml:"ports"`
+	Extra map[string]string `yaml:"extra,omitempty"`
+}
+
+func (s *SafeToolTestSuite) TestYamlify() {
+	s.Run("round trip", func() {
+		in := yamlConfig{Name: "svc", Ports: []int{80, 443}}
+		out, err := Yamlify(in)
+		s.NoError(err)
+		s.Equal("name: svc\nports:\n  - 80\n  - 443\n", out)
+
+		var back yamlConfig
+		s.NoError(Unyamlify(out, &back))
+		s.Equal(in, back)
+	})
+
+	s.Run("errors", func() {
+		_, err := Yamlify(func() {})
+		s.Error(err)
+
+		var cfg yamlConfig
+		s.Error(Unyamlify([]byte("ports: [a"), &cfg))
+		s.Error(Unyamlify("name: [1]", &cfg))
+	})
+}
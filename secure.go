@@ -0,0 +1,15 @@
+package tool
+
+import (
+	"crypto/subtle"
+)
+
+// SecureEqual Compares secrets in constant time, only the length of the inputs may leak
+func SecureEqual(a, b []byte) bool {
+	return subtle.ConstantTimeCompare(a, b) == 1
+}
+
+// SecureEqualStr String version of SecureEqual
+func SecureEqualStr(a, b string) bool {
+	return SecureEqual([]byte(a), []byte(b))
+}
@@ -0,0 +1,11 @@
+package tool
+
+func (s *ToolTestSuite) TestSecureEqual() {
+	s.True(SecureEqual([]byte("token"), []byte("token")))
+	s.False(SecureEqual([]byte("token"), []byte("tokem")))
+	s.False(SecureEqual([]byte("token"), []byte("token2")))
+	s.True(SecureEqual(nil, []byte{}))
+
+	s.True(SecureEqualStr("token", "token"))
+	s.False(SecureEqualStr("token", ""))
+}
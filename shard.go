@@ -0,0 +1,39 @@
+package tool
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/iamwavecut/tool/safetool"
+)
+
+// ShardWork Assigns items to shards by pick(item)%shards and calls f for every non-empty shard concurrently.
+// Items of a shard keep their input order, so items with the same pick value are processed in order.
+// Returns errors of all failed shards combined with safetool.CombineErrors, each annotated with the shard number.
+func ShardWork[T any](items []T, shards int, pick func(T) uint64, f func(shard int, items []T) error) error {
+	if shards < 1 {
+		shards = 1
+	}
+	buckets := make([][]T, shards)
+	for _, item := range items {
+		shard := pick(item) % uint64(shards)
+		buckets[shard] = append(buckets[shard], item)
+	}
+
+	errs := make([]error, shards)
+	var wg sync.WaitGroup
+	for shard, bucket := range buckets {
+		if len(bucket) == 0 {
+			continue
+		}
+		wg.Add(1)
+		go func(shard int, bucket []T) {
+			defer wg.Done()
+			if err := f(shard, bucket); err != nil {
+				errs[shard] = fmt.Errorf("shard %d: %w", shard, err)
+			}
+		}(shard, bucket)
+	}
+	wg.Wait()
+	return safetool.CombineErrors(errs...)
+}
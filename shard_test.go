@@ -0,0 +1,51 @@
+package tool
+
+import (
+	"errors"
+	"sync"
+)
+
+func (s *ToolTestSuite) TestShardWork() {
+	type event struct {
+		Key uint64
+		Seq int
+	}
+	var items []event
+	for seq := 0; seq < 100; seq++ {
+		items = append(items, event{Key: uint64(seq % 7), Seq: seq})
+	}
+
+	var mu sync.Mutex
+	got := map[int][]event{}
+	err := ShardWork(items, 3, func(e event) uint64 { return e.Key }, func(shard int, batch []event) error {
+		mu.Lock()
+		defer mu.Unlock()
+		got[shard] = append(got[shard], batch...)
+		return nil
+	})
+	s.NoError(err)
+
+	total := 0
+	for shard, batch := range got {
+		total += len(batch)
+		for i, e := range batch {
+			s.Equal(shard, int(e.Key%3))
+			if i > 0 {
+				s.Less(batch[i-1].Seq, e.Seq, "shard keeps input order")
+			}
+		}
+	}
+	s.Equal(len(items), total)
+
+	errOdd := errors.New("odd shard")
+	err = ShardWork([]int{0, 1, 2, 3}, 4, func(n int) uint64 { return uint64(n) }, func(shard int, _ []int) error {
+		if shard%2 == 1 {
+			return errOdd
+		}
+		return nil
+	})
+	s.ErrorIs(err, errOdd)
+	s.ErrorContains(err, "shard 1: odd shard")
+	s.ErrorContains(err, "shard 3: odd shard")
+	s.NoError(ShardWork(nil, 0, func(int) uint64 { return 0 }, func(int, []int) error { return errOdd }))
+}
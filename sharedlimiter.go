@@ -0,0 +1,105 @@
+package tool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"time"
+)
+
+const sharedLimiterLockRetry = 5 * time.Millisecond
+
+type (
+	// SharedLimiter Token bucket rate limiter persisted to a file, so it is shared by all processes using the same path.
+	// Useful for parallel short-lived CLI invocations hitting the same rate-limited API.
+	SharedLimiter struct {
+		path  string
+		rate  float64
+		burst float64
+	}
+
+	sharedLimiterState struct {
+		Tokens  float64 `json:"tokens"`
+		Updated int64   `json:"updated"`
+	}
+)
+
+// NewSharedLimiter Returns a limiter allowing rate events per second with optional burst, values below 1 mean 1.
+// State is kept in path, path+".lock" is used to serialize access.
+func NewSharedLimiter(path string, rate float64, burst ...int) *SharedLimiter {
+	if rate <= 0 {
+		panic("rate must be positive")
+	}
+	b := 1
+	if len(burst) > 0 && burst[0] > 0 {
+		b = burst[0]
+	}
+	return &SharedLimiter{path: path, rate: rate, burst: float64(b)}
+}
+
+// Allow Takes a token if one is available right now
+func (l *SharedLimiter) Allow() (bool, error) {
+	wait, err := l.take(context.Background())
+	return wait == 0, err
+}
+
+// Wait Blocks until a token is taken or ctx is done
+func (l *SharedLimiter) Wait(ctx context.Context) error {
+	for {
+		wait, err := l.take(ctx)
+		if err != nil || wait == 0 {
+			return err
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// take Takes a token and returns 0, or returns time until the next token is available
+func (l *SharedLimiter) take(ctx context.Context) (wait time.Duration, err error) {
+	for {
+		err = WithFileLock(l.path+".lock", func() error {
+			state := sharedLimiterState{Tokens: l.burst}
+			b, err := os.ReadFile(l.path)
+			switch {
+			case err == nil && len(b) > 0:
+				if err = json.Unmarshal(b, &state); err != nil {
+					return err
+				}
+			case err != nil && !errors.Is(err, os.ErrNotExist):
+				return err
+			}
+
+			now := time.Now()
+			if state.Updated > 0 {
+				elapsed := now.Sub(time.Unix(0, state.Updated)).Seconds()
+				state.Tokens += elapsed * l.rate
+				if state.Tokens > l.burst {
+					state.Tokens = l.burst
+				}
+			}
+			state.Updated = now.UnixNano()
+			if state.Tokens >= 1 {
+				state.Tokens--
+			} else {
+				wait = time.Duration((1 - state.Tokens) / l.rate * float64(time.Second))
+			}
+			return os.WriteFile(l.path, Jsonify(state).Bytes(), 0o644)
+		})
+		if !errors.Is(err, ErrLocked) {
+			return wait, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-time.After(sharedLimiterLockRetry):
+		}
+	}
+}
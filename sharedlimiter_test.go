@@ -0,0 +1,60 @@
+package tool
+
+import (
+	"context"
+	"path/filepath"
+	"time"
+)
+
+func (s *ToolTestSuite) TestSharedLimiter() {
+	dir := s.T().TempDir()
+
+	s.Run("allow", func() {
+		path := filepath.Join(dir, "allow.json")
+		first := NewSharedLimiter(path, 0.01, 2)
+		second := NewSharedLimiter(path, 0.01, 2)
+
+		for _, l := range []*SharedLimiter{first, second} {
+			ok, err := l.Allow()
+			s.NoError(err)
+			s.True(ok)
+		}
+		ok, err := first.Allow()
+		s.NoError(err)
+		s.False(ok)
+	})
+
+	s.Run("wait", func() {
+		l := NewSharedLimiter(filepath.Join(dir, "wait.json"), 100)
+		start := time.Now()
+		for i := 0; i < 3; i++ {
+			s.NoError(l.Wait(context.Background()))
+		}
+		s.GreaterOrEqual(time.Since(start), 15*time.Millisecond)
+	})
+
+	s.Run("canceled", func() {
+		l := NewSharedLimiter(filepath.Join(dir, "canceled.json"), 0.01)
+		s.NoError(l.Wait(context.Background()))
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		s.ErrorIs(l.Wait(ctx), context.DeadlineExceeded)
+	})
+
+	s.Run("invalid rate", func() {
+		s.Panics(func() { NewSharedLimiter(filepath.Join(dir, "invalid.json"), 0) })
+	})
+}
+
+func (s *ToolTestSuite) TestSharedLimiterBurst() {
+	dir := s.T().TempDir()
+
+	l := NewSharedLimiter(filepath.Join(dir, "negative.json"), 0.01, -5)
+	ok, err := l.Allow()
+	s.NoError(err)
+	s.True(ok, "negative burst means 1")
+
+	burst := make([]int, 1, 2)
+	NewSharedLimiter(filepath.Join(dir, "zero.json"), 0.01, burst...)
+	s.Equal([]int{0, 0}, burst[:2], "caller's backing array is untouched")
+}
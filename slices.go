@@ -0,0 +1,164 @@
+package tool
+
+import (
+	"github.com/iamwavecut/tool/safetool"
+	"golang.org/x/exp/constraints"
+)
+
+// Unique Returns new slice of the first occurrences of every value, in the input order
+func Unique[T comparable](s []T) []T {
+	return safetool.Unique(s)
+}
+
+// UniqueBy Returns new slice of the first elements having every key, in the input order,
+// e.g. UniqueBy(users, func(u User) int64 { return u.ID })
+func UniqueBy[T any, K comparable](s []T, key func(T) K) []T {
+	if s == nil {
+		return nil
+	}
+	seen := make(map[K]struct{}, len(s))
+	out := make([]T, 0, len(s))
+	for _, v := range s {
+		k := key(v)
+		if _, ok := seen[k]; ok {
+			continue
+		}
+		seen[k] = struct{}{}
+		out = append(out, v)
+	}
+	return out
+}
+
+// DedupAdjacent Returns new slice with runs of equal consecutive values collapsed into one, like uniq(1)
+func DedupAdjacent[T comparable](s []T) []T {
+	if s == nil {
+		return nil
+	}
+	out := make([]T, 0, len(s))
+	for i, v := range s {
+		if i > 0 && v == s[i-1] {
+			continue
+		}
+		out = append(out, v)
+	}
+	return out
+}
+
+// Reverse Reverses s in place
+func Reverse[T any](s []T) {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+}
+
+// Rotate Rotates s in place by n positions to the left, negative n rotates to the right,
+// e.g. Rotate([1 2 3 4], 1) is [2 3 4 1]
+func Rotate[T any](s []T, n int) {
+	if len(s) == 0 {
+		return
+	}
+	n %= len(s)
+	if n < 0 {
+		n += len(s)
+	}
+	Reverse(s[:n])
+	Reverse(s[n:])
+	Reverse(s)
+}
+
+// RemoveAt Removes s[i] keeping the order, returns s shortened by one.
+// Shares the backing array with s: following elements are shifted and the freed last slot is zeroed.
+// Panics if i is out of range.
+func RemoveAt[T any](s []T, i int) []T {
+	_ = s[i]
+	copy(s[i:], s[i+1:])
+	var zero T
+	s[len(s)-1] = zero
+	return s[:len(s)-1]
+}
+
+// RemoveSwap Removes s[i] in O(1) by moving the last element in its place, returns s shortened by one.
+// Shares the backing array with s, the freed last slot is zeroed. Panics if i is out of range.
+func RemoveSwap[T any](s []T, i int) []T {
+	last := len(s) - 1
+	s[i] = s[last]
+	var zero T
+	s[last] = zero
+	return s[:last]
+}
+
+// InsertAt Inserts values before s[i], i may be len(s) to append, returns the grown slice.
+// Like append, reuses the backing array of s when it has enough capacity, so s itself must not be used afterwards.
+// Panics if i is out of range.
+func InsertAt[T any](s []T, i int, values ...T) []T {
+	_ = s[i:]
+	n := len(s) + len(values)
+	if n > cap(s) {
+		grown := make([]T, n, n+n/4)
+		copy(grown, s[:i])
+		copy(grown[i:], values)
+		copy(grown[i+len(values):], s[i:])
+		return grown
+	}
+	s = s[:n]
+	copy(s[i+len(values):], s[i:])
+	copy(s[i:], values)
+	return s
+}
+
+// SearchBy Binary searches slice sorted ascending by key, returns index of the first element with target key and true,
+// or the index target would be inserted at and false
+func SearchBy[T any, K constraints.Ordered](sorted []T, key func(T) K, target K) (int, bool) {
+	lo, hi := 0, len(sorted)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if key(sorted[mid]) < target {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo, lo < len(sorted) && key(sorted[lo]) == target
+}
+
+// InsertSorted Inserts v into slice sorted ascending by key after the elements with equal key, returns the grown slice.
+// Aliasing is the same as in InsertAt.
+func InsertSorted[T any, K constraints.Ordered](sorted []T, v T, key func(T) K) []T {
+	k := key(v)
+	lo, hi := 0, len(sorted)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if key(sorted[mid]) <= k {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return InsertAt(sorted, lo, v)
+}
+
+// EqualSlices Reports whether a and b have the same length and equal elements in the same order, nil equals empty
+func EqualSlices[T comparable](a, b []T) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// EqualMaps Reports whether a and b have the same keys with equal values, nil equals empty
+func EqualMaps[K, V comparable](a, b map[K]V) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, va := range a {
+		if vb, ok := b[k]; !ok || va != vb {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,93 @@
+package tool
+
+func (s *ToolTestSuite) TestUnique() {
+	s.Equal([]int{3, 1, 2}, Unique([]int{3, 1, 3, 2, 1}))
+	s.Nil(Unique[int](nil))
+	s.Equal([]string{}, Unique([]string{}))
+
+	type user struct {
+		ID   int
+		Name string
+	}
+	users := []user{{1, "a"}, {2, "b"}, {1, "c"}}
+	s.Equal([]user{{1, "a"}, {2, "b"}}, UniqueBy(users, func(u user) int { return u.ID }))
+
+	in := []int{1, 1, 2, 2, 2, 1, 3, 3}
+	s.Equal([]int{1, 2, 1, 3}, DedupAdjacent(in))
+	s.Equal([]int{1, 1, 2, 2, 2, 1, 3, 3}, in, "input is not modified")
+	s.Nil(DedupAdjacent[string](nil))
+}
+
+func (s *ToolTestSuite) TestSliceOps() {
+	in := []int{1, 2, 3, 4, 5}
+	Reverse(in)
+	s.Equal([]int{5, 4, 3, 2, 1}, in)
+	Reverse([]int{})
+
+	in = []int{1, 2, 3, 4}
+	Rotate(in, 1)
+	s.Equal([]int{2, 3, 4, 1}, in)
+	Rotate(in, -2)
+	s.Equal([]int{4, 1, 2, 3}, in)
+	Rotate(in, 9)
+	s.Equal([]int{1, 2, 3, 4}, in)
+	Rotate([]int(nil), 3)
+
+	in = []int{1, 2, 3, 4}
+	out := RemoveAt(in, 1)
+	s.Equal([]int{1, 3, 4}, out)
+	s.Equal([]int{1, 3, 4, 0}, in, "backing array is shared and the tail is zeroed")
+	s.Panics(func() { RemoveAt(out, 3) })
+
+	in = []int{1, 2, 3, 4}
+	s.Equal([]int{1, 4, 3}, RemoveSwap(in, 1))
+	s.Equal([]int{1, 2, 3}, RemoveSwap([]int{1, 2, 3, 4}, 3))
+	s.Panics(func() { RemoveSwap([]int{}, 0) })
+
+	s.Equal([]int{0, 1, 2}, InsertAt([]int{1, 2}, 0, 0))
+	s.Equal([]int{1, 2, 3, 4}, InsertAt([]int{1, 4}, 1, 2, 3))
+	s.Equal([]int{1, 2, 3}, InsertAt([]int{1, 2}, 2, 3))
+	roomy := make([]int, 2, 10)
+	s.Equal([]int{0, 9, 0}, InsertAt(roomy, 1, 9))
+	s.Equal([]int{0, 9}, roomy, "capacity is reused")
+	s.Panics(func() { InsertAt([]int{1}, 2, 0) })
+}
+
+func (s *ToolTestSuite) TestSearchBy() {
+	type slot struct {
+		At   int
+		Name string
+	}
+	at := func(s slot) int { return s.At }
+	schedule := []slot{{10, "a"}, {20, "b"}, {20, "c"}, {30, "d"}}
+
+	i, ok := SearchBy(schedule, at, 20)
+	s.True(ok)
+	s.Equal(1, i)
+	i, ok = SearchBy(schedule, at, 25)
+	s.False(ok)
+	s.Equal(3, i)
+	i, ok = SearchBy(schedule, at, 99)
+	s.False(ok)
+	s.Equal(4, i)
+	i, ok = SearchBy([]slot(nil), at, 1)
+	s.False(ok)
+	s.Zero(i)
+
+	schedule = InsertSorted(schedule, slot{20, "e"}, at)
+	schedule = InsertSorted(schedule, slot{5, "f"}, at)
+	schedule = InsertSorted(schedule, slot{40, "g"}, at)
+	s.Equal([]slot{{5, "f"}, {10, "a"}, {20, "b"}, {20, "c"}, {20, "e"}, {30, "d"}, {40, "g"}}, schedule)
+}
+
+func (s *ToolTestSuite) TestEqual() {
+	s.True(EqualSlices([]int{1, 2}, []int{1, 2}))
+	s.True(EqualSlices(nil, []string{}))
+	s.False(EqualSlices([]int{1, 2}, []int{2, 1}))
+	s.False(EqualSlices([]int{1}, []int{1, 1}))
+
+	s.True(EqualMaps(map[string]int{"a": 1, "b": 2}, map[string]int{"b": 2, "a": 1}))
+	s.True(EqualMaps(nil, map[int]int{}))
+	s.False(EqualMaps(map[string]int{"a": 1}, map[string]int{"a": 2}))
+	s.False(EqualMaps(map[string]int{"a": 0}, map[string]int{"b": 0}))
+}
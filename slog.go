@@ -0,0 +1,105 @@
+package tool
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+// toolPackage Import path of this package, frames of its non-test files are skipped when looking for the caller
+var toolPackage = reflect.TypeOf(logger{}).PkgPath()
+
+// SetSlogLogger Sets tool package logger to l, pass nil to disable logging.
+// Console and LogError emit records with caller, file, line and error attributes, and the record source
+// points to the code calling the tool package, so slog.HandlerOptions.AddSource reports it as well.
+// Every record carries a build group with BuildInfo version, revision and Go version.
+func SetSlogLogger(l *slog.Logger) {
+	if l == nil {
+		tooloLog = &logger{}
+		return
+	}
+	l = l.With(buildAttr())
+	tooloLog = &logger{l: slogStd{l}, slog: l}
+}
+
+// buildAttr Returns build group of non-empty BuildInfo fields
+func buildAttr() slog.Attr {
+	info := BuildInfo()
+	var attrs []any
+	if info.Version != "" {
+		attrs = append(attrs, slog.String("version", info.Version))
+	}
+	if info.Revision != "" {
+		attrs = append(attrs, slog.String("revision", info.Revision))
+	}
+	if info.Dirty {
+		attrs = append(attrs, slog.Bool("dirty", true))
+	}
+	attrs = append(attrs, slog.String("goVersion", info.GoVersion))
+	return slog.Group("build", attrs...)
+}
+
+// logAttrs Emits a structured record attributed to the first caller outside the package
+func (l *logger) logAttrs(level slog.Level, msg string, attrs ...slog.Attr) {
+	ctx := context.Background()
+	if !l.slog.Enabled(ctx, level) {
+		return
+	}
+	pc, frame := externalCaller()
+	r := slog.NewRecord(clockNow(), level, msg, pc)
+	if frame.Function != "" {
+		r.AddAttrs(
+			slog.String("caller", fmt.Sprintf("%s:%d", funcPackage(frame.Function), frame.Line)),
+			slog.String("file", frame.File),
+			slog.Int("line", frame.Line),
+		)
+	}
+	r.AddAttrs(attrs...)
+	_ = l.slog.Handler().Handle(ctx, r)
+}
+
+// externalCaller Returns program counter and frame of the closest caller outside of the package non-test files
+func externalCaller() (uintptr, runtime.Frame) {
+	var pcs [32]uintptr
+	n := runtime.Callers(2, pcs[:])
+	for _, pc := range pcs[:n] {
+		frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+		if isToolFrame(frame) {
+			continue
+		}
+		return pc, frame
+	}
+	return 0, runtime.Frame{}
+}
+
+// isToolFrame Reports whether frame belongs to the package non-test files
+func isToolFrame(frame runtime.Frame) bool {
+	pkg := funcPackage(frame.Function)
+	return (pkg == toolPackage || strings.HasPrefix(pkg, toolPackage+"/")) && !strings.HasSuffix(frame.File, "_test.go")
+}
+
+// slogStd Adapts slog.Logger to StdLogger for the unstructured log calls
+type slogStd struct {
+	l *slog.Logger
+}
+
+func (s slogStd) Println(a ...any) {
+	s.l.Info(strings.TrimSuffix(fmt.Sprintln(a...), "\n"))
+}
+
+func (s slogStd) Panicln(a ...any) {
+	msg := strings.TrimSuffix(fmt.Sprintln(a...), "\n")
+	s.l.Error(msg)
+	panic(msg)
+}
+
+func (s slogStd) Printf(format string, a ...any) {
+	s.l.Info(fmt.Sprintf(format, a...))
+}
+
+func (s slogStd) Print(a ...any) {
+	s.l.Info(fmt.Sprint(a...))
+}
@@ -0,0 +1,117 @@
+package tool
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+)
+
+func (s *ToolTestSuite) TestSetSlogLogger() {
+	var buf bytes.Buffer
+	SetSlogLogger(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{AddSource: true})))
+	defer SetLogger(testLog)
+
+	records := func() []map[string]any {
+		var res []map[string]any
+		for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+			var record map[string]any
+			s.Require().NoError(json.Unmarshal([]byte(line), &record))
+			res = append(res, record)
+		}
+		buf.Reset()
+		return res
+	}
+
+	s.Run("console", func() {
+		Console("value", 1)
+		rec := records()
+		s.Require().Len(rec, 1)
+		s.Equal("INFO", rec[0]["level"])
+		s.Equal("value 1", rec[0]["msg"])
+		s.Equal(toolPackage+":28", rec[0]["caller"])
+		s.True(strings.HasSuffix(rec[0]["file"].(string), "slog_test.go"))
+		s.Equal(float64(28), rec[0]["line"])
+		s.Equal(float64(28), rec[0]["source"].(map[string]any)["line"])
+	})
+
+	s.Run("error", func() {
+		s.True(Try(errors.New("broken"), true))
+		rec := records()
+		s.Require().Len(rec, 1)
+		s.Equal("ERROR", rec[0]["level"])
+		s.Equal(map[string]any{"message": "broken", "type": "*errors.errorString"}, rec[0]["error"])
+		s.Equal(float64(40), rec[0]["line"])
+	})
+
+	s.Run("plain", func() {
+		ConsoleGroup("group")()
+		rec := records()
+		s.Require().Len(rec, 2)
+		s.Contains(rec[0]["msg"], "group")
+	})
+
+	s.Run("disabled", func() {
+		SetSlogLogger(nil)
+		Console("nothing")
+		Try(errors.New("nothing"), true)
+		s.Empty(buf.String())
+	})
+}
+
+func (s *ToolTestSuite) TestConsoleKV() {
+	s.Equal([]slog.Attr{slog.String("user", "bob"), slog.Int("n", 2), slog.Bool("ok", true)},
+		Fields("user", "bob", "n", 2, slog.Bool("ok", true)))
+	s.Equal([]slog.Attr{slog.Int("!BADKEY", 1)}, Fields(1))
+
+	s.Run("text", func() {
+		testLog.buf = ""
+		ConsoleKV("saved", "user", "bob smith", "n", 2, slog.Group("req", "id", "x1"), "empty", "")
+		s.Contains(testLog.buf, `]> saved user="bob smith" n=2 req.id=x1 empty=""`+"\n")
+	})
+
+	s.Run("json", func() {
+		var buf bytes.Buffer
+		SetSlogLogger(slog.New(slog.NewJSONHandler(&buf, nil)))
+		defer SetLogger(testLog)
+
+		ConsoleKV("saved", "user", "bob", "n", 2, slog.Group("req", "id", "x1"))
+		var record map[string]any
+		s.Require().NoError(json.Unmarshal(buf.Bytes(), &record))
+		s.Equal("saved", record["msg"])
+		s.Equal("bob", record["user"])
+		s.Equal(float64(2), record["n"])
+		s.Equal(map[string]any{"id": "x1"}, record["req"])
+	})
+}
+
+func (s *ToolTestSuite) TestSlogErrorAttr() {
+	var attr error
+	SetSlogLogger(slog.New(slog.NewJSONHandler(&bytes.Buffer{}, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == "error" {
+				attr, _ = a.Value.Any().(error)
+			}
+			return a
+		},
+	})))
+	defer SetLogger(testLog)
+
+	base := errors.New("broken")
+	Try(base, true)
+	s.ErrorIs(attr, base, "handlers see the error attribute as an error")
+}
+
+func (s *ToolTestSuite) TestSlogBuildInfo() {
+	var buf bytes.Buffer
+	SetSlogLogger(slog.New(slog.NewJSONHandler(&buf, nil)))
+	defer SetLogger(testLog)
+
+	Console("value")
+	var record struct {
+		Build map[string]any `json:"build"`
+	}
+	s.Require().NoError(json.Unmarshal(buf.Bytes(), &record))
+	s.Equal(BuildInfo().GoVersion, record.Build["goVersion"])
+}
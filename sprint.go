@@ -0,0 +1,231 @@
+package tool
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// PrintMaxNodes Maximal number of values rendered by SafeSprint and Sdump, the rest is replaced with "…"
+var PrintMaxNodes = 10000
+
+// SafeSprint Returns fmt %+v representation of v that never hangs: maps, slices and interfaces leading back
+// to a value being printed are rendered as <cycle>, output stops after PrintMaxNodes values,
+// and panics of String and Error methods are reported inline like fmt does.
+// Nested pointers are printed as addresses, as with fmt.
+func SafeSprint(v any) string {
+	return sprintValue(reflect.ValueOf(v))
+}
+
+func sprintValue(val reflect.Value) string {
+	p := &sprinter{budget: PrintMaxNodes, visited: map[dumpRef]bool{}}
+	p.value(val, 0)
+	return p.buf.String()
+}
+
+type sprinter struct {
+	buf     strings.Builder
+	budget  int
+	visited map[dumpRef]bool
+}
+
+var (
+	errorType    = reflect.TypeOf((*error)(nil)).Elem()
+	stringerType = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+)
+
+func (p *sprinter) value(val reflect.Value, depth int) {
+	if p.budget <= 0 {
+		if p.budget == 0 {
+			p.buf.WriteString("…")
+			p.budget--
+		}
+		return
+	}
+	p.budget--
+
+	if !val.IsValid() {
+		p.buf.WriteString("<nil>")
+		return
+	}
+	if p.method(val) {
+		return
+	}
+
+	switch val.Kind() {
+	case reflect.Interface:
+		if val.IsNil() {
+			p.buf.WriteString("<nil>")
+			return
+		}
+		p.value(val.Elem(), depth)
+
+	case reflect.Ptr:
+		switch {
+		case val.IsNil():
+			p.buf.WriteString("<nil>")
+		case depth == 0 && In(val.Elem().Kind(), reflect.Struct, reflect.Slice, reflect.Array, reflect.Map):
+			if p.enter(val) {
+				return
+			}
+			defer p.leave(val)
+			p.buf.WriteByte('&')
+			p.value(val.Elem(), depth+1)
+		default:
+			p.buf.WriteString("0x" + strconv.FormatUint(uint64(val.Pointer()), 16))
+		}
+
+	case reflect.Struct:
+		p.buf.WriteByte('{')
+		for i := 0; i < val.NumField(); i++ {
+			if i > 0 {
+				p.buf.WriteByte(' ')
+			}
+			p.buf.WriteString(val.Type().Field(i).Name + ":")
+			p.value(val.Field(i), depth+1)
+		}
+		p.buf.WriteByte('}')
+
+	case reflect.Slice, reflect.Array:
+		if val.Kind() == reflect.Slice && val.Len() > 0 {
+			if p.enter(val) {
+				return
+			}
+			defer p.leave(val)
+		}
+		p.buf.WriteByte('[')
+		for i := 0; i < val.Len() && p.budget >= 0; i++ {
+			if i > 0 {
+				p.buf.WriteByte(' ')
+			}
+			p.value(val.Index(i), depth+1)
+		}
+		p.buf.WriteByte(']')
+
+	case reflect.Map:
+		if val.Len() > 0 {
+			if p.enter(val) {
+				return
+			}
+			defer p.leave(val)
+		}
+		p.buf.WriteString("map[")
+		for i, key := range sortedMapKeys(val) {
+			if p.budget < 0 {
+				break
+			}
+			if i > 0 {
+				p.buf.WriteByte(' ')
+			}
+			p.value(key, depth+1)
+			p.buf.WriteByte(':')
+			p.value(val.MapIndex(key), depth+1)
+		}
+		p.buf.WriteByte(']')
+
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		if val.IsNil() {
+			p.buf.WriteString("<nil>")
+			return
+		}
+		p.buf.WriteString("0x" + strconv.FormatUint(uint64(val.Pointer()), 16))
+
+	case reflect.Bool:
+		p.buf.WriteString(strconv.FormatBool(val.Bool()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		p.buf.WriteString(strconv.FormatInt(val.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		p.buf.WriteString(strconv.FormatUint(val.Uint(), 10))
+	case reflect.Float32, reflect.Float64:
+		p.buf.WriteString(strconv.FormatFloat(val.Float(), 'g', -1, val.Type().Bits()))
+	case reflect.Complex64, reflect.Complex128:
+		p.buf.WriteString(strconv.FormatComplex(val.Complex(), 'g', -1, val.Type().Bits()))
+	case reflect.String:
+		p.buf.WriteString(val.String())
+	}
+}
+
+// method Writes result of Error or String method if val has one and is accessible, as fmt does
+func (p *sprinter) method(val reflect.Value) (handled bool) {
+	if !val.CanInterface() || val.Kind() == reflect.Interface {
+		return false
+	}
+	t := val.Type()
+	if !t.Implements(errorType) && !t.Implements(stringerType) {
+		return false
+	}
+	if val.Kind() == reflect.Ptr && val.IsNil() {
+		p.buf.WriteString("<nil>")
+		return true
+	}
+	if p.enter(val) {
+		return true
+	}
+	defer p.leave(val)
+	name := "String"
+	if t.Implements(errorType) {
+		name = "Error"
+	}
+	defer func() {
+		if e := recover(); e != nil {
+			p.buf.WriteString(fmt.Sprintf("%%!v(PANIC=%s method: %v)", name, e))
+			handled = true
+		}
+	}()
+	switch v := val.Interface().(type) {
+	case error:
+		p.buf.WriteString(v.Error())
+	case fmt.Stringer:
+		p.buf.WriteString(v.String())
+	}
+	return true
+}
+
+// enter Marks referenced value as being printed, returns true and writes cycle marker if it already is
+func (p *sprinter) enter(val reflect.Value) bool {
+	if !In(val.Kind(), reflect.Ptr, reflect.Map, reflect.Slice) {
+		return false
+	}
+	ref := dumpRef{val.Pointer(), val.Type()}
+	if p.visited[ref] {
+		p.buf.WriteString("<cycle>")
+		return true
+	}
+	p.visited[ref] = true
+	return false
+}
+
+func (p *sprinter) leave(val reflect.Value) {
+	if In(val.Kind(), reflect.Ptr, reflect.Map, reflect.Slice) {
+		delete(p.visited, dumpRef{val.Pointer(), val.Type()})
+	}
+}
+
+// sortedMapKeys Returns keys ordered like fmt does for basic kinds, by their representation otherwise
+func sortedMapKeys(val reflect.Value) []reflect.Value {
+	keys := val.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		for a.Kind() == reflect.Interface && b.Kind() == reflect.Interface && !a.IsNil() && !b.IsNil() {
+			a, b = a.Elem(), b.Elem()
+		}
+		if a.Kind() == b.Kind() {
+			switch a.Kind() {
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+				return a.Int() < b.Int()
+			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+				return a.Uint() < b.Uint()
+			case reflect.Float32, reflect.Float64:
+				return a.Float() < b.Float()
+			case reflect.String:
+				return a.String() < b.String()
+			case reflect.Bool:
+				return !a.Bool() && b.Bool()
+			}
+		}
+		return sprintValue(keys[i]) < sprintValue(keys[j])
+	})
+	return keys
+}
@@ -0,0 +1,51 @@
+package tool
+
+import "fmt"
+
+type panicStringer struct{}
+
+func (panicStringer) String() string { panic("boom") }
+
+func (s *ToolTestSuite) TestSafeSprint() {
+	s.Run("fmt parity", func() {
+		for _, v := range []any{
+			nil, 1, -2.5, "str", true, []int{1, 2}, [2]string{"a", "b"},
+			map[string]int{"b": 2, "a": 1}, struct{ int }{123}, &dumpInner{N: 1, Tags: []string{"x"}},
+			Varchar("v"), fmt.Errorf("failed"), complex(1, 2),
+		} {
+			s.Equal(fmt.Sprintf("%+v", v), SafeSprint(v), "%#v", v)
+		}
+	})
+
+	s.Run("cycles", func() {
+		m := map[string]any{"a": 1}
+		m["self"] = m
+		s.Equal("map[a:1 self:<cycle>]", SafeSprint(m))
+
+		sl := []any{1, nil}
+		sl[1] = sl
+		s.Equal("[1 <cycle>]", SafeSprint(sl))
+
+		node := &dumpNode{Name: "n"}
+		node.Next = node
+		s.Contains(SafeSprint(node), "&{Name:n Next:0x")
+	})
+
+	s.Run("budget", func() {
+		defer func(n int) { PrintMaxNodes = n }(PrintMaxNodes)
+		PrintMaxNodes = 4
+		s.Equal("[1 2 3 …]", SafeSprint([]int{1, 2, 3, 4, 5, 6}))
+		s.Equal("[]int{1, 2, 3, …}", Sdump([]int{1, 2, 3, 4, 5, 6}))
+
+		deep := []any{nil}
+		for i := 0; i < 100; i++ {
+			deep = []any{deep}
+		}
+		s.Equal("[[…]]", SafeSprint(deep))
+	})
+
+	s.Run("panicking stringer", func() {
+		s.Equal("[%!v(PANIC=String method: boom)]", SafeSprint([]panicStringer{{}}))
+		s.Equal(fmt.Sprint(panicStringer{}), SafeSprint(panicStringer{}))
+	})
+}
@@ -0,0 +1,83 @@
+package tool
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// DBTag Struct tag naming the column a field is scanned from, "-" skips the field
+const DBTag = "db"
+
+// ScanRows Scans all rows into structs of type T, matching columns to fields by `db` tags,
+// falling back to case-insensitive field names. Fields of embedded structs are matched too.
+// NULLs need pointer fields or sql.Null* types, like with Rows.Scan. A column without field is an error.
+// Closes rows.
+func ScanRows[T any](rows *sql.Rows) ([]T, error) {
+	defer rows.Close()
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	if typ.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("scan rows: %s is not a struct", typ)
+	}
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	fields := dbFields(typ)
+	indexes := make([][]int, len(columns))
+	for i, column := range columns {
+		index, ok := fields[strings.ToLower(column)]
+		if !ok {
+			return nil, fmt.Errorf("scan rows: column %q has no field in %s", column, typ)
+		}
+		indexes[i] = index
+	}
+
+	out := []T{}
+	dest := make([]any, len(columns))
+	for rows.Next() {
+		var item T
+		val := reflect.ValueOf(&item).Elem()
+		for i, index := range indexes {
+			dest[i] = val.FieldByIndex(index).Addr().Interface()
+		}
+		if err = rows.Scan(dest...); err != nil {
+			return nil, fmt.Errorf("scan rows: row %d: %w", len(out), err)
+		}
+		out = append(out, item)
+	}
+	return out, rows.Err()
+}
+
+// dbFields Returns field indexes of typ by lower-cased column name, embedded struct pointers are not followed
+func dbFields(typ reflect.Type) map[string][]int {
+	fields := map[string][]int{}
+	for _, sf := range reflect.VisibleFields(typ) {
+		if !sf.IsExported() || sf.Anonymous && sf.Type.Kind() == reflect.Struct {
+			continue
+		}
+		name := sf.Tag.Get(DBTag)
+		if name == "-" || viaPointer(typ, sf.Index) {
+			continue
+		}
+		name = strings.ToLower(NonZero(name, sf.Name))
+		if _, ok := fields[name]; !ok || len(sf.Index) < len(fields[name]) {
+			fields[name] = sf.Index
+		}
+	}
+	return fields
+}
+
+// viaPointer Reports whether field at index is promoted through an embedded pointer
+func viaPointer(typ reflect.Type, index []int) bool {
+	for _, i := range index[:len(index)-1] {
+		sf := typ.Field(i)
+		if sf.Type.Kind() == reflect.Pointer {
+			return true
+		}
+		typ = sf.Type
+	}
+	return false
+}
@@ -0,0 +1,93 @@
+package tool
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+)
+
+type (
+	rowsTestDriver struct{}
+	rowsTestConn   struct{}
+	rowsTestRows   struct {
+		columns []string
+		values  [][]driver.Value
+	}
+)
+
+func (rowsTestDriver) Open(string) (driver.Conn, error) { return rowsTestConn{}, nil }
+
+func (rowsTestConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (rowsTestConn) Close() error                        { return nil }
+func (rowsTestConn) Begin() (driver.Tx, error)           { return nil, errors.New("not supported") }
+
+// QueryContext Returns users table rows, query is the comma-separated list of columns
+func (rowsTestConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	all := map[string][]driver.Value{
+		"id":         {int64(1), int64(2)},
+		"user_name":  {"alice", "bob"},
+		"email":      {"a@example.com", nil},
+		"nickname":   {nil, "b"},
+		"created_by": {"root", "root"},
+		"extra":      {1, 2},
+	}
+	rows := &rowsTestRows{columns: strings.Split(query, ",")}
+	for i := 0; i < 2; i++ {
+		row := make([]driver.Value, len(rows.columns))
+		for j, column := range rows.columns {
+			row[j] = all[column][i]
+		}
+		rows.values = append(rows.values, row)
+	}
+	return rows, nil
+}
+
+func (r *rowsTestRows) Columns() []string { return r.columns }
+func (r *rowsTestRows) Close() error      { return nil }
+func (r *rowsTestRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
+
+func init() {
+	sql.Register("tool-rows-test", rowsTestDriver{})
+}
+
+func (s *ToolTestSuite) TestScanRows() {
+	type audit struct {
+		CreatedBy string `db:"created_by"`
+	}
+	type user struct {
+		audit
+		ID       int64
+		Name     string `db:"user_name"`
+		Email    sql.NullString
+		Nickname *string
+		Ignored  string `db:"-"`
+	}
+
+	db := MustReturn(sql.Open("tool-rows-test", ""))
+	defer db.Close()
+
+	users, err := ScanRows[user](MustReturn(db.Query("id,user_name,email,nickname,created_by")))
+	s.NoError(err)
+	s.Equal([]user{
+		{audit: audit{"root"}, ID: 1, Name: "alice", Email: sql.NullString{String: "a@example.com", Valid: true}},
+		{audit: audit{"root"}, ID: 2, Name: "bob", Nickname: Ptr("b")},
+	}, users)
+
+	_, err = ScanRows[user](MustReturn(db.Query("id,extra")))
+	s.ErrorContains(err, `column "extra" has no field`)
+
+	_, err = ScanRows[user](MustReturn(db.Query("email")))
+	s.NoError(err)
+	_, err = ScanRows[struct{ Email string }](MustReturn(db.Query("email")))
+	s.ErrorContains(err, "row 1")
+}
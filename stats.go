@@ -0,0 +1,171 @@
+package tool
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+type (
+	// EMA Exponential moving average, safe for concurrent use
+	EMA struct {
+		mu     sync.Mutex
+		alpha  float64
+		value  float64
+		primed bool
+	}
+
+	// RollingWindow Keeps samples over a sliding time window, safe for concurrent use.
+	// Pushing 1 for failures and 0 for successes makes Avg the error rate of the window.
+	RollingWindow struct {
+		mu      sync.Mutex
+		window  time.Duration
+		samples []sample
+	}
+
+	// RateTracker Counts events over a sliding time window, safe for concurrent use
+	RateTracker struct {
+		w *RollingWindow
+	}
+
+	sample struct {
+		at    time.Time
+		value float64
+	}
+)
+
+// NewEMA Returns an EMA with smoothing factor alpha in (0, 1], higher alpha favors recent values
+func NewEMA(alpha float64) *EMA {
+	if alpha <= 0 || alpha > 1 {
+		panic("alpha must be in (0, 1]")
+	}
+	return &EMA{alpha: alpha}
+}
+
+// Add Adds the value and returns the updated average, first value becomes the average as is
+func (e *EMA) Add(v float64) float64 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if !e.primed {
+		e.value, e.primed = v, true
+	} else {
+		e.value += e.alpha * (v - e.value)
+	}
+	return e.value
+}
+
+// Value Returns the current average
+func (e *EMA) Value() float64 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.value
+}
+
+// NewRollingWindow Returns a window keeping samples added within the last window duration
+func NewRollingWindow(window time.Duration) *RollingWindow {
+	return &RollingWindow{window: window}
+}
+
+// Add Adds the sample
+func (w *RollingWindow) Add(v float64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	now := clockNow()
+	w.prune(now)
+	w.samples = append(w.samples, sample{at: now, value: v})
+}
+
+// Count Returns number of samples in the window
+func (w *RollingWindow) Count() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.prune(clockNow())
+	return len(w.samples)
+}
+
+// Sum Returns sum of samples in the window
+func (w *RollingWindow) Sum() float64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.prune(clockNow())
+	var sum float64
+	for _, s := range w.samples {
+		sum += s.value
+	}
+	return sum
+}
+
+// Avg Returns average of samples in the window, 0 if empty
+func (w *RollingWindow) Avg() float64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.prune(clockNow())
+	if len(w.samples) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, s := range w.samples {
+		sum += s.value
+	}
+	return sum / float64(len(w.samples))
+}
+
+// Percentile Returns p-th percentile (0-100) of samples in the window using nearest-rank method, 0 if empty
+func (w *RollingWindow) Percentile(p float64) float64 {
+	w.mu.Lock()
+	values := make([]float64, 0, len(w.samples))
+	w.prune(clockNow())
+	for _, s := range w.samples {
+		values = append(values, s.value)
+	}
+	w.mu.Unlock()
+
+	if len(values) == 0 {
+		return 0
+	}
+	sort.Float64s(values)
+	rank := int(math.Ceil(p / 100 * float64(len(values))))
+	if rank < 1 {
+		rank = 1
+	} else if rank > len(values) {
+		rank = len(values)
+	}
+	return values[rank-1]
+}
+
+// P95 Returns 95th percentile of samples in the window
+func (w *RollingWindow) P95() float64 {
+	return w.Percentile(95)
+}
+
+func (w *RollingWindow) prune(now time.Time) {
+	cutoff := now.Add(-w.window)
+	i := sort.Search(len(w.samples), func(i int) bool { return w.samples[i].at.After(cutoff) })
+	if i > 0 {
+		w.samples = append(w.samples[:0], w.samples[i:]...)
+	}
+}
+
+// NewRateTracker Returns a tracker counting events within the last window duration, window must be positive
+func NewRateTracker(window time.Duration) *RateTracker {
+	if window <= 0 {
+		panic("window must be positive")
+	}
+	return &RateTracker{w: NewRollingWindow(window)}
+}
+
+// Add Records n events
+func (r *RateTracker) Add(n int) {
+	r.w.Add(float64(n))
+}
+
+// Total Returns number of events in the window
+func (r *RateTracker) Total() int {
+	return int(r.w.Sum())
+}
+
+// Rate Returns events per second averaged over the window
+func (r *RateTracker) Rate() float64 {
+	return r.w.Sum() / r.w.window.Seconds()
+}
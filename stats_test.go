@@ -0,0 +1,47 @@
+package tool
+
+import (
+	"time"
+)
+
+func (s *ToolTestSuite) TestEMA() {
+	e := NewEMA(0.5)
+	s.Equal(10.0, e.Add(10))
+	s.Equal(15.0, e.Add(20))
+	s.Equal(15.0, e.Value())
+	s.Panics(func() { NewEMA(0) })
+}
+
+func (s *ToolTestSuite) TestRollingWindow() {
+	s.Run("stats", func() {
+		w := NewRollingWindow(time.Minute)
+		s.Zero(w.Avg())
+		s.Zero(w.P95())
+		for i := 1; i <= 20; i++ {
+			w.Add(float64(i))
+		}
+		s.Equal(20, w.Count())
+		s.Equal(210.0, w.Sum())
+		s.Equal(10.5, w.Avg())
+		s.Equal(19.0, w.P95())
+		s.Equal(1.0, w.Percentile(0))
+		s.Equal(20.0, w.Percentile(100))
+	})
+	s.Run("expiry", func() {
+		w := NewRollingWindow(20 * time.Millisecond)
+		w.Add(1)
+		time.Sleep(30 * time.Millisecond)
+		w.Add(2)
+		s.Equal(1, w.Count())
+		s.Equal(2.0, w.Sum())
+	})
+}
+
+func (s *ToolTestSuite) TestRateTracker() {
+	r := NewRateTracker(2 * time.Second)
+	r.Add(3)
+	r.Add(1)
+	s.Equal(4, r.Total())
+	s.Equal(2.0, r.Rate())
+	s.Panics(func() { NewRateTracker(0) })
+}
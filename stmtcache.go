@@ -0,0 +1,179 @@
+package tool
+
+import (
+	"container/list"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"sync"
+)
+
+type (
+	// StmtCache Prepared statements of db keyed by query text, least recently used ones are closed above the limit.
+	// Statements failing with a lost connection are re-prepared and the call is retried once.
+	StmtCache struct {
+		db    *sql.DB
+		limit int
+		mu    sync.Mutex
+		lru   *list.List
+		items map[string]*list.Element
+	}
+
+	stmtEntry struct {
+		query   string
+		stmt    *sql.Stmt
+		refs    int
+		evicted bool
+	}
+)
+
+// NewStmtCache Returns cache keeping up to limit prepared statements, limit<1 means unlimited
+func NewStmtCache(db *sql.DB, limit int) *StmtCache {
+	return &StmtCache{db: db, limit: limit, lru: list.New(), items: map[string]*list.Element{}}
+}
+
+// Exec Executes cached statement of query with args
+func (c *StmtCache) Exec(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return withCachedStmt(ctx, c, query, func(stmt *sql.Stmt) (sql.Result, error) {
+		return stmt.ExecContext(ctx, args...)
+	})
+}
+
+// Query Runs cached statement of query with args
+func (c *StmtCache) Query(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return withCachedStmt(ctx, c, query, func(stmt *sql.Stmt) (*sql.Rows, error) {
+		return stmt.QueryContext(ctx, args...)
+	})
+}
+
+// QueryRow Runs cached statement of query with args, expecting at most one row.
+// If the statement cannot be prepared, the query runs uncached so the returned row carries the error.
+func (c *StmtCache) QueryRow(ctx context.Context, query string, args ...any) *sql.Row {
+	row, _ := withCachedStmt(ctx, c, query, func(stmt *sql.Stmt) (*sql.Row, error) {
+		row := stmt.QueryRowContext(ctx, args...)
+		return row, row.Err()
+	})
+	if row == nil {
+		return c.db.QueryRowContext(ctx, query, args...)
+	}
+	return row
+}
+
+// Tx Returns cached statement of query bound to tx, e.g. inside WithTx, it is closed with the transaction
+func (c *StmtCache) Tx(ctx context.Context, tx *sql.Tx, query string) (*sql.Stmt, error) {
+	entry, err := c.acquire(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release(entry)
+	return tx.StmtContext(ctx, entry.stmt), nil
+}
+
+// Close Closes all cached statements
+func (c *StmtCache) Close() error {
+	c.mu.Lock()
+	var entries []*stmtEntry
+	for query, elem := range c.items {
+		entries = append(entries, c.evict(query, elem)...)
+	}
+	c.mu.Unlock()
+
+	var errs []error
+	for _, entry := range entries {
+		errs = append(errs, entry.stmt.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// withCachedStmt Calls fn with cached statement of query, re-preparing it once if the connection is lost
+func withCachedStmt[R any](ctx context.Context, c *StmtCache, query string, fn func(*sql.Stmt) (R, error)) (R, error) {
+	var res R
+	for attempt := 0; ; attempt++ {
+		entry, err := c.acquire(ctx, query)
+		if err != nil {
+			return res, err
+		}
+		res, err = fn(entry.stmt)
+		lost := errors.Is(err, driver.ErrBadConn) || errors.Is(err, sql.ErrConnDone)
+		if lost {
+			c.discard(entry)
+		}
+		c.release(entry)
+		if !lost || attempt > 0 {
+			return res, err
+		}
+	}
+}
+
+// acquire Returns entry of query, prepared if missing, and marks it used until release
+func (c *StmtCache) acquire(ctx context.Context, query string) (*stmtEntry, error) {
+	c.mu.Lock()
+	if elem, ok := c.items[query]; ok {
+		c.lru.MoveToFront(elem)
+		entry := elem.Value.(*stmtEntry)
+		entry.refs++
+		c.mu.Unlock()
+		return entry, nil
+	}
+	c.mu.Unlock()
+
+	stmt, err := c.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	if elem, ok := c.items[query]; ok { // prepared concurrently
+		c.lru.MoveToFront(elem)
+		entry := elem.Value.(*stmtEntry)
+		entry.refs++
+		c.mu.Unlock()
+		return entry, stmt.Close()
+	}
+	entry := &stmtEntry{query: query, stmt: stmt, refs: 1}
+	c.items[query] = c.lru.PushFront(entry)
+	var closing []*stmtEntry
+	for c.limit > 0 && c.lru.Len() > c.limit {
+		oldest := c.lru.Back()
+		closing = append(closing, c.evict(oldest.Value.(*stmtEntry).query, oldest)...)
+	}
+	c.mu.Unlock()
+
+	for _, evicted := range closing {
+		_ = evicted.stmt.Close()
+	}
+	return entry, nil
+}
+
+// release Marks entry unused, closes it if it was evicted meanwhile
+func (c *StmtCache) release(entry *stmtEntry) {
+	c.mu.Lock()
+	entry.refs--
+	closing := entry.evicted && entry.refs == 0
+	c.mu.Unlock()
+	if closing {
+		_ = entry.stmt.Close()
+	}
+}
+
+// discard Evicts entry if it is still cached, so the next acquire prepares the query again
+func (c *StmtCache) discard(entry *stmtEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.items[entry.query]; ok && elem.Value == entry {
+		c.evict(entry.query, elem)
+	}
+}
+
+// evict Removes cached element, returns its entry if it is unused and must be closed by the caller, under mu
+func (c *StmtCache) evict(query string, elem *list.Element) []*stmtEntry {
+	entry := elem.Value.(*stmtEntry)
+	c.lru.Remove(elem)
+	delete(c.items, query)
+	entry.evicted = true
+	if entry.refs > 0 {
+		return nil
+	}
+	return []*stmtEntry{entry}
+}
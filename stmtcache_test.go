@@ -0,0 +1,170 @@
+package tool
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"sync"
+)
+
+type (
+	stmtTestDriver struct {
+		mu       sync.Mutex
+		prepared map[string]int
+		closed   map[string]int
+		badConns int
+	}
+	stmtTestConn struct{ d *stmtTestDriver }
+	stmtTestStmt struct {
+		d     *stmtTestDriver
+		query string
+	}
+	stmtTestRows struct{ done bool }
+)
+
+var errStmtTestSyntax = errors.New("syntax error")
+
+func (d *stmtTestDriver) Open(string) (driver.Conn, error) { return stmtTestConn{d}, nil }
+
+func (d *stmtTestDriver) reset(badConns int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.prepared, d.closed, d.badConns = map[string]int{}, map[string]int{}, badConns
+}
+
+func (d *stmtTestDriver) count(counts map[string]int, query string) int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return counts[query]
+}
+
+func (c stmtTestConn) Prepare(query string) (driver.Stmt, error) {
+	c.d.mu.Lock()
+	defer c.d.mu.Unlock()
+	c.d.prepared[query]++
+	if query == "invalid" {
+		return nil, errStmtTestSyntax
+	}
+	return stmtTestStmt{c.d, query}, nil
+}
+func (c stmtTestConn) Close() error              { return nil }
+func (c stmtTestConn) Begin() (driver.Tx, error) { return stmtTestTx{}, nil }
+
+type stmtTestTx struct{}
+
+func (stmtTestTx) Commit() error   { return nil }
+func (stmtTestTx) Rollback() error { return nil }
+
+func (s stmtTestStmt) Close() error {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+	s.d.closed[s.query]++
+	return nil
+}
+func (s stmtTestStmt) NumInput() int { return -1 }
+
+func (s stmtTestStmt) Exec([]driver.Value) (driver.Result, error) {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+	if s.d.badConns > 0 {
+		s.d.badConns--
+		return nil, driver.ErrBadConn
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (s stmtTestStmt) Query([]driver.Value) (driver.Rows, error) { return &stmtTestRows{}, nil }
+
+func (r *stmtTestRows) Columns() []string { return []string{"query"} }
+func (r *stmtTestRows) Close() error      { return nil }
+func (r *stmtTestRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done, dest[0] = true, "ok"
+	return nil
+}
+
+var stmtDriver = &stmtTestDriver{}
+
+func init() {
+	sql.Register("tool-stmt-test", stmtDriver)
+}
+
+func (s *ToolTestSuite) TestStmtCache() {
+	db := MustReturn(sql.Open("tool-stmt-test", ""))
+	defer db.Close()
+	db.SetMaxIdleConns(1)
+	ctx := context.Background()
+
+	s.Run("reuse and eviction", func() {
+		stmtDriver.reset(0)
+		cache := NewStmtCache(db, 2)
+		for _, query := range []string{"a", "b", "a", "c", "a"} {
+			var res string
+			s.NoError(cache.QueryRow(ctx, query).Scan(&res))
+			s.Equal("ok", res)
+		}
+		s.Equal(1, stmtDriver.count(stmtDriver.prepared, "a"))
+		s.Equal(1, stmtDriver.count(stmtDriver.closed, "b"), "least recently used is closed")
+		s.Zero(stmtDriver.count(stmtDriver.closed, "a"))
+
+		rows, err := cache.Query(ctx, "d")
+		s.NoError(err)
+		s.NoError(cache.Close())
+		s.True(rows.Next(), "rows outlive closed statement")
+		s.NoError(rows.Close())
+		s.Equal(1, stmtDriver.count(stmtDriver.closed, "a"))
+		s.Equal(1, stmtDriver.count(stmtDriver.closed, "d"))
+	})
+
+	s.Run("re-prepare on lost connection", func() {
+		stmtDriver.reset(3) // database/sql retries bad connections itself, then gives up
+		cache := NewStmtCache(db, 0)
+		defer cache.Close()
+		res, err := cache.Exec(ctx, "update")
+		s.NoError(err)
+		s.Equal(int64(1), MustReturn(res.RowsAffected()))
+		s.Len(cache.items, 1)
+
+		stmtDriver.reset(100)
+		_, err = cache.Exec(ctx, "update")
+		s.True(errors.Is(err, driver.ErrBadConn))
+	})
+
+	s.Run("with tx", func() {
+		stmtDriver.reset(0)
+		cache := NewStmtCache(db, 0)
+		defer cache.Close()
+		cached := func() *sql.Stmt { return cache.items["insert"].Value.(*stmtEntry).stmt }
+		var inTx *sql.Stmt
+		s.NoError(WithTx(ctx, db, nil, func(tx *sql.Tx) error {
+			stmt, err := cache.Tx(ctx, tx, "insert")
+			if err != nil {
+				return err
+			}
+			inTx = cached()
+			_, err = stmt.ExecContext(ctx)
+			return err
+		}))
+		_, err := cache.Exec(ctx, "insert")
+		s.NoError(err)
+		s.Same(inTx, cached())
+	})
+}
+
+func (s *ToolTestSuite) TestStmtCacheQueryRowPrepareError() {
+	db := MustReturn(sql.Open("tool-stmt-test", ""))
+	defer db.Close()
+	stmtDriver.reset(0)
+	cache := NewStmtCache(db, 0)
+	defer cache.Close()
+
+	var res string
+	row := cache.QueryRow(context.Background(), "invalid")
+	s.NotNil(row)
+	s.ErrorIs(row.Scan(&res), errStmtTestSyntax)
+	s.Empty(cache.items)
+}
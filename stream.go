@@ -0,0 +1,201 @@
+package tool
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/iamwavecut/tool/safetool"
+)
+
+// WritePolicy Error handling of MultiWriterErrPolicy
+type WritePolicy int
+
+const (
+	// WriteFailFast Stops at the first failed writer, like io.MultiWriter
+	WriteFailFast WritePolicy = iota
+	// WriteBestEffort Writes to every writer, returns errors of all failed ones combined
+	WriteBestEffort
+)
+
+type (
+	// CountingReader Reader counting bytes read through it, safe to query concurrently
+	CountingReader struct {
+		r     io.Reader
+		total atomic.Int64
+	}
+
+	// CountingWriter Writer counting bytes written through it, safe to query concurrently
+	CountingWriter struct {
+		w     io.Writer
+		total atomic.Int64
+	}
+
+	multiWriter struct {
+		policy  WritePolicy
+		writers []io.Writer
+	}
+
+	teeReader struct {
+		r  io.Reader
+		fn func(chunk []byte)
+	}
+
+	throttledReader struct {
+		r io.Reader
+		throttle
+	}
+
+	throttledWriter struct {
+		w io.Writer
+		throttle
+	}
+
+	// throttle Paces transferred bytes to rate per second, bursting at most a second worth of idle time
+	throttle struct {
+		mu    sync.Mutex
+		rate  int64
+		start time.Time
+		done  int64
+	}
+)
+
+// NewCountingReader Returns r counting bytes read
+func NewCountingReader(r io.Reader) *CountingReader {
+	return &CountingReader{r: r}
+}
+
+// Read Implements io.Reader
+func (c *CountingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.total.Add(int64(n))
+	return n, err
+}
+
+// Total Returns number of bytes read so far
+func (c *CountingReader) Total() int64 {
+	return c.total.Load()
+}
+
+// NewCountingWriter Returns w counting bytes written
+func NewCountingWriter(w io.Writer) *CountingWriter {
+	return &CountingWriter{w: w}
+}
+
+// Write Implements io.Writer
+func (c *CountingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.total.Add(int64(n))
+	return n, err
+}
+
+// Total Returns number of bytes written so far
+func (c *CountingWriter) Total() int64 {
+	return c.total.Load()
+}
+
+// ThrottledReader Returns r limited to bytesPerSec on average, reads block to keep the pace.
+// bytesPerSec<=0 returns r as is.
+func ThrottledReader(r io.Reader, bytesPerSec int64) io.Reader {
+	if bytesPerSec <= 0 {
+		return r
+	}
+	return &throttledReader{r: r, throttle: throttle{rate: bytesPerSec}}
+}
+
+// Read Implements io.Reader, reads at most a second worth of bytes at once
+func (t *throttledReader) Read(p []byte) (int, error) {
+	if int64(len(p)) > t.rate {
+		p = p[:t.rate]
+	}
+	n, err := t.r.Read(p)
+	t.wait(n)
+	return n, err
+}
+
+// ThrottledWriter Returns w limited to bytesPerSec on average, writes are split into chunks and block to keep the pace.
+// bytesPerSec<=0 returns w as is.
+func ThrottledWriter(w io.Writer, bytesPerSec int64) io.Writer {
+	if bytesPerSec <= 0 {
+		return w
+	}
+	return &throttledWriter{w: w, throttle: throttle{rate: bytesPerSec}}
+}
+
+// Write Implements io.Writer
+func (t *throttledWriter) Write(p []byte) (written int, err error) {
+	for len(p) > 0 {
+		chunk := p[:min(int64(len(p)), t.rate)]
+		n, err := t.w.Write(chunk)
+		written += n
+		t.wait(n)
+		if err != nil {
+			return written, err
+		}
+		p = p[n:]
+	}
+	return written, nil
+}
+
+// wait Accounts n transferred bytes and sleeps until the pace allows them
+func (t *throttle) wait(n int) {
+	t.mu.Lock()
+	now := clockNow()
+	if t.start.IsZero() || now.Sub(t.due()) > time.Second {
+		t.start, t.done = now, 0
+	}
+	t.done += int64(n)
+	delay := t.due().Sub(now)
+	t.mu.Unlock()
+	if delay > 0 {
+		clockSleep(delay)
+	}
+}
+
+// due Returns time when all bytes done are allowed, under mu
+func (t *throttle) due() time.Time {
+	return t.start.Add(time.Duration(float64(t.done) / float64(t.rate) * float64(time.Second)))
+}
+
+// MultiWriterErrPolicy Returns writer duplicating writes to all writers, errors are handled according to policy.
+// Errors are annotated with the writer index, short writes are reported as io.ErrShortWrite.
+func MultiWriterErrPolicy(policy WritePolicy, writers ...io.Writer) io.Writer {
+	return &multiWriter{policy: policy, writers: append([]io.Writer(nil), writers...)}
+}
+
+// Write Implements io.Writer, with WriteBestEffort reports len(p) written along with the errors
+func (m *multiWriter) Write(p []byte) (int, error) {
+	var errs []error
+	for i, w := range m.writers {
+		n, err := w.Write(p)
+		if err == nil && n < len(p) {
+			err = io.ErrShortWrite
+		}
+		if err == nil {
+			continue
+		}
+		err = fmt.Errorf("writer %d: %w", i, err)
+		if m.policy == WriteFailFast {
+			return n, err
+		}
+		errs = append(errs, err)
+	}
+	return len(p), safetool.CombineErrors(errs...)
+}
+
+// TeeReaderFunc Returns reader passing every chunk read from r to fn before returning it.
+// The chunk is only valid during the call, fn must copy it to retain.
+func TeeReaderFunc(r io.Reader, fn func(chunk []byte)) io.Reader {
+	return &teeReader{r: r, fn: fn}
+}
+
+// Read Implements io.Reader
+func (t *teeReader) Read(p []byte) (int, error) {
+	n, err := t.r.Read(p)
+	if n > 0 {
+		t.fn(p[:n])
+	}
+	return n, err
+}
@@ -0,0 +1,85 @@
+package tool
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"time"
+)
+
+func (s *ToolTestSuite) TestCounting() {
+	r := NewCountingReader(strings.NewReader("hello world"))
+	var buf bytes.Buffer
+	w := NewCountingWriter(&buf)
+	_, err := io.Copy(w, r)
+	s.NoError(err)
+	s.Equal(int64(11), r.Total())
+	s.Equal(int64(11), w.Total())
+	s.Equal("hello world", buf.String())
+}
+
+func (s *ToolTestSuite) TestThrottled() {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewManualClock(start)
+	SetClock(clock)
+	defer SetClock(nil)
+
+	data := strings.Repeat("x", 1000)
+	out, err := io.ReadAll(ThrottledReader(strings.NewReader(data), 100))
+	s.NoError(err)
+	s.Equal(data, string(out))
+	s.Equal(10*time.Second, clock.Now().Sub(start))
+
+	clock.Advance(time.Hour)
+	start = clock.Now()
+	var buf bytes.Buffer
+	cw := NewCountingWriter(&buf)
+	n, err := ThrottledWriter(cw, 250).Write([]byte(data))
+	s.NoError(err)
+	s.Equal(1000, n)
+	s.Equal(int64(1000), cw.Total())
+	s.Equal(4*time.Second, clock.Now().Sub(start))
+
+	r := strings.NewReader(data)
+	s.Same(r, ThrottledReader(r, 0))
+}
+
+type failingWriter struct{ short bool }
+
+func (w failingWriter) Write(p []byte) (int, error) {
+	if w.short {
+		return len(p) / 2, nil
+	}
+	return 0, io.ErrClosedPipe
+}
+
+func (s *ToolTestSuite) TestMultiWriterErrPolicy() {
+	var first, last bytes.Buffer
+	n, err := MultiWriterErrPolicy(WriteFailFast, &first, failingWriter{}, &last).Write([]byte("log"))
+	s.ErrorIs(err, io.ErrClosedPipe)
+	s.ErrorContains(err, "writer 1")
+	s.Zero(n)
+	s.Equal("log", first.String())
+	s.Empty(last.String())
+
+	first.Reset()
+	n, err = MultiWriterErrPolicy(WriteBestEffort, &first, failingWriter{}, failingWriter{short: true}, &last).Write([]byte("log"))
+	s.Equal(3, n)
+	s.ErrorIs(err, io.ErrClosedPipe)
+	s.ErrorIs(err, io.ErrShortWrite)
+	s.Equal("log", first.String())
+	s.Equal("log", last.String())
+
+	n, err = MultiWriterErrPolicy(WriteBestEffort, &first).Write([]byte("!"))
+	s.NoError(err)
+	s.Equal(1, n)
+}
+
+func (s *ToolTestSuite) TestTeeReaderFunc() {
+	var seen []string
+	r := TeeReaderFunc(strings.NewReader("abcdef"), func(chunk []byte) { seen = append(seen, string(chunk)) })
+	out, err := io.ReadAll(io.LimitReader(r, 6))
+	s.NoError(err)
+	s.Equal("abcdef", string(out))
+	s.Equal("abcdef", strings.Join(seen, ""))
+}
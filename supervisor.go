@@ -0,0 +1,209 @@
+package tool
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/iamwavecut/tool/safetool"
+)
+
+var (
+	// ErrJobExists Returned by Supervisor.Add for an already registered name
+	ErrJobExists = errors.New("job already exists")
+	// ErrSupervisorStopped Returned by Supervisor.Add after Shutdown
+	ErrSupervisorStopped = errors.New("supervisor is stopped")
+)
+
+// JobState Lifecycle state of a supervised job
+type JobState string
+
+const (
+	// JobRunning Job is running
+	JobRunning JobState = "running"
+	// JobRestarting Job failed and waits for the restart
+	JobRestarting JobState = "restarting"
+	// JobDone Job returned nil and won't be restarted
+	JobDone JobState = "done"
+	// JobFailed Job exhausted its restarts
+	JobFailed JobState = "failed"
+	// JobStopped Job was stopped by Shutdown or the supervisor context
+	JobStopped JobState = "stopped"
+)
+
+type (
+	// JobPolicy Restart policy of a supervised job, adjusted with options
+	JobPolicy struct {
+		// MaxRestarts Number of restarts after errors or panics, <0 restarts infinitely
+		MaxRestarts int
+		// Backoff Delay policy between restarts, nil restarts immediately
+		Backoff safetool.Backoff
+		// StableAfter Run duration after which the job counts as healthy and Backoff starts over from
+		// the first attempt, <=0 never starts over
+		StableAfter time.Duration
+	}
+
+	// JobStatus Snapshot of a supervised job
+	JobStatus struct {
+		Name      string
+		State     JobState
+		Restarts  int
+		StartedAt time.Time
+		// LastError Last error returned or panic recovered, as *JobPanicError
+		LastError error
+	}
+
+	// Supervisor Runs named long-running jobs, restarting them on errors and panics as their policies say
+	Supervisor struct {
+		ctx    context.Context
+		cancel context.CancelFunc
+		wg     sync.WaitGroup
+
+		mu      sync.Mutex
+		stopped bool
+		jobs    []*JobStatus
+	}
+)
+
+// DefaultJobPolicy Used as the base of Supervisor.Add options
+var DefaultJobPolicy = JobPolicy{
+	MaxRestarts: -1,
+	Backoff:     safetool.ExponentialWithJitter(100*time.Millisecond, 30*time.Second, 0.2),
+	StableAfter: time.Minute,
+}
+
+// WithMaxRestarts Sets number of restarts after errors or panics, <0 restarts infinitely
+func WithMaxRestarts(n int) Option[JobPolicy] {
+	return func(p *JobPolicy) { p.MaxRestarts = n }
+}
+
+// WithRestartBackoff Sets delay policy between restarts
+func WithRestartBackoff(backoff safetool.Backoff) Option[JobPolicy] {
+	return func(p *JobPolicy) { p.Backoff = backoff }
+}
+
+// WithStableAfter Sets run duration after which Backoff starts over from the first attempt, <=0 never starts over
+func WithStableAfter(d time.Duration) Option[JobPolicy] {
+	return func(p *JobPolicy) { p.StableAfter = d }
+}
+
+// NewSupervisor Returns supervisor running jobs with contexts derived from ctx
+func NewSupervisor(ctx context.Context) *Supervisor {
+	s := &Supervisor{}
+	s.ctx, s.cancel = context.WithCancel(ctx)
+	return s
+}
+
+// Add Registers and starts job run under name, options are applied over DefaultJobPolicy.
+// run should return once its context is done.
+func (s *Supervisor) Add(name string, run func(ctx context.Context) error, opts ...Option[JobPolicy]) error {
+	policy, err := Build(DefaultJobPolicy, opts...)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stopped {
+		return ErrSupervisorStopped
+	}
+	for _, job := range s.jobs {
+		if job.Name == name {
+			return ErrJobExists
+		}
+	}
+	status := &JobStatus{Name: name, State: JobRunning, StartedAt: clockNow()}
+	s.jobs = append(s.jobs, status)
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.supervise(status, run, policy)
+	}()
+	return nil
+}
+
+// Status Returns snapshot of all jobs in the order of registration
+func (s *Supervisor) Status() []JobStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	res := make([]JobStatus, len(s.jobs))
+	for i, job := range s.jobs {
+		res[i] = *job
+	}
+	return res
+}
+
+// Shutdown Cancels contexts of all jobs and waits for them to return, or for ctx to be done
+func (s *Supervisor) Shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	s.stopped = true
+	s.mu.Unlock()
+	s.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *Supervisor) supervise(status *JobStatus, run func(ctx context.Context) error, policy JobPolicy) {
+	delayAttempt := 0
+	for attempt := 1; ; attempt++ {
+		started := clockNow()
+		var err error
+		if panicErr := recoverPanic(func() { err = run(s.ctx) }, status.Name, attempt); panicErr != nil {
+			err = panicErr
+		}
+
+		s.mu.Lock()
+		switch {
+		case s.ctx.Err() != nil:
+			status.State = JobStopped
+		case err == nil:
+			status.State = JobDone
+		case policy.MaxRestarts >= 0 && status.Restarts >= policy.MaxRestarts:
+			status.State, status.LastError = JobFailed, err
+		default:
+			status.State, status.LastError = JobRestarting, err
+		}
+		state := status.State
+		s.mu.Unlock()
+		if state != JobRestarting {
+			return
+		}
+		tooloLog.LogErrorAt(LevelWarn, err, "job "+status.Name+" restarting")
+
+		delayAttempt++
+		if policy.StableAfter > 0 && clockNow().Sub(started) >= policy.StableAfter {
+			delayAttempt = 1
+		}
+		var delay time.Duration
+		if policy.Backoff != nil {
+			delay = policy.Backoff.Next(delayAttempt)
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-s.ctx.Done():
+			timer.Stop()
+			s.mu.Lock()
+			status.State = JobStopped
+			s.mu.Unlock()
+			return
+		case <-timer.C:
+		}
+
+		s.mu.Lock()
+		status.State, status.StartedAt = JobRunning, clockNow()
+		status.Restarts++
+		s.mu.Unlock()
+	}
+}
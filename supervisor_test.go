@@ -0,0 +1,92 @@
+package tool
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/iamwavecut/tool/safetool"
+)
+
+func (s *ToolTestSuite) TestSupervisor() {
+	SetLogger(nil)
+	defer SetLogger(testLog)
+
+	sup := NewSupervisor(context.Background())
+	noDelay := WithRestartBackoff(safetool.Constant(0))
+	errBoom := errors.New("boom")
+
+	var flakyRuns atomic.Int32
+	s.NoError(sup.Add("flaky", func(ctx context.Context) error {
+		if flakyRuns.Add(1) < 3 {
+			panic("boom")
+		}
+		<-ctx.Done()
+		return nil
+	}, noDelay))
+	s.NoError(sup.Add("broken", func(context.Context) error { return errBoom }, noDelay, WithMaxRestarts(2)))
+	s.NoError(sup.Add("oneshot", func(context.Context) error { return nil }))
+	s.ErrorIs(sup.Add("oneshot", func(context.Context) error { return nil }), ErrJobExists)
+
+	s.Eventually(func() bool {
+		status := sup.Status()
+		return status[0].Restarts == 2 && status[1].State == JobFailed && status[2].State == JobDone
+	}, time.Second, time.Millisecond)
+
+	status := sup.Status()
+	s.Equal([]string{"flaky", "broken", "oneshot"}, []string{status[0].Name, status[1].Name, status[2].Name})
+	s.Equal(JobRunning, status[0].State)
+	var panicErr *JobPanicError
+	s.ErrorAs(status[0].LastError, &panicErr)
+	s.Equal(2, status[1].Restarts)
+	s.ErrorIs(status[1].LastError, errBoom)
+
+	s.NoError(sup.Shutdown(context.Background()))
+	s.Equal(JobStopped, sup.Status()[0].State)
+	s.ErrorIs(sup.Add("late", func(context.Context) error { return nil }), ErrSupervisorStopped)
+
+	stuck := NewSupervisor(context.Background())
+	release := make(chan struct{})
+	defer close(release)
+	s.NoError(stuck.Add("stuck", func(context.Context) error { <-release; return nil }))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	s.ErrorIs(stuck.Shutdown(ctx), context.DeadlineExceeded)
+}
+
+func (s *ToolTestSuite) TestSupervisorBackoff() {
+	SetLogger(nil)
+	defer SetLogger(testLog)
+	clock := NewManualClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	SetClock(clock)
+	defer SetClock(nil)
+
+	sup := NewSupervisor(context.Background())
+	errBoom := errors.New("boom")
+	s.NoError(sup.Add("nil backoff", func(context.Context) error { return errBoom },
+		WithRestartBackoff(nil), WithMaxRestarts(2)))
+
+	var (
+		runs     int
+		attempts []int
+	)
+	s.NoError(sup.Add("stable", func(context.Context) error {
+		runs++
+		if runs == 3 {
+			clock.Advance(time.Hour)
+		}
+		return errBoom
+	}, WithMaxRestarts(4), WithStableAfter(time.Minute), WithRestartBackoff(safetool.BackoffFunc(func(attempt int) time.Duration {
+		attempts = append(attempts, attempt)
+		return 0
+	}))))
+
+	s.Eventually(func() bool {
+		status := sup.Status()
+		return status[0].State == JobFailed && status[1].State == JobFailed
+	}, time.Second, time.Millisecond)
+	s.NoError(sup.Shutdown(context.Background()))
+	s.Equal(2, sup.Status()[0].Restarts)
+	s.Equal([]int{1, 2, 1, 2}, attempts)
+}
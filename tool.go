@@ -2,22 +2,27 @@
 package tool
 
 import (
-	"crypto/rand"
 	"encoding/json"
 	"errors"
 	"fmt"
 	stdlog "log"
-	"math/big"
+	"log/slog"
 	"path/filepath"
 	"reflect"
 	"runtime"
+	"runtime/debug"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"text/template"
 	"time"
 
+	"golang.org/x/exp/constraints"
 	"golang.org/x/exp/slices"
 
-	"golang.org/x/exp/constraints"
+	"github.com/iamwavecut/tool/safetool"
 )
 
 type (
@@ -36,6 +41,8 @@ type (
 
 	logger struct {
 		l StdLogger
+		// slog Set by SetSlogLogger, receives structured records instead of formatted lines
+		slog *slog.Logger
 	}
 
 	Varchar string
@@ -43,40 +50,93 @@ type (
 	catchableError struct {
 		error
 	}
+
+	// PanicError Foreign panic value re-raised by Catch, carries the stack captured at recovery
+	PanicError struct {
+		Value any
+		Stack []byte
+	}
 )
 
 // Unwrap Returns the wrapped error
 func (e catchableError) Unwrap() error { return e.error }
 
+// Error Implements error
+func (e *PanicError) Error() string { return fmt.Sprintf("panic: %v", e.Value) }
+
+// Unwrap Returns the panic value if it is an error
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// ErrTimeout Raised by MustWithin when the function does not finish in time
+var ErrTimeout = errors.New("timeout")
+
 // tooloLog Package level logger, defaults to log.Default()
 var tooloLog = &logger{l: stdlog.Default()}
 
-func getRelativePath(filePath string) string {
-	relPath, err := filepath.Rel(filepath.Dir(findRootCaller()), filePath)
+// Console Prints %+v of arguments rendered with SafeSprint, great to debug stuff
+func Console(obj ...interface{}) {
+	if tooloLog.slog != nil {
+		if LevelInfo.enabled() {
+			tooloLog.logAttrs(slog.LevelInfo, deepString(obj...))
+		}
+		return
+	}
+	prefix, err := callerPrefix(2)
+	if err != nil {
+		tooloLog.LogError(err)
+		return
+	}
+	tooloLog.LogDeep(append([]interface{}{prefix}, obj...)...)
+}
+
+// ConsoleGroup Prints name and indents Console output until the returned closer is called,
+// the closer prints elapsed time. Groups nest, great to debug multi-step flows:
+//
+//	defer tool.ConsoleGroup("sync")()
+func ConsoleGroup(name string) func() {
+	prefix, err := callerPrefix(2)
 	if err != nil {
-		return filePath // return the original file path if error
+		tooloLog.LogError(err)
+		return func() {}
+	}
+	tooloLog.LogText(prefix + " " + name)
+	atomic.AddInt32(&consoleDepth, 1)
+
+	start := clockNow()
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			atomic.AddInt32(&consoleDepth, -1)
+			tooloLog.LogText(prefix + " " + name + " done in " + clockSince(start).String())
+		})
 	}
-	return relPath
 }
 
-// Console Prints %+v of arguments, great to debug stuff
-func Console(obj ...interface{}) {
-	pc, _, line, ok := runtime.Caller(1)
+// consoleDepth Nesting level of ConsoleGroup, indents Console output
+var consoleDepth int32
+
+// callerPrefix Returns "[pkg:line]>" prefix of the caller skip frames above, indented by ConsoleGroup nesting
+func callerPrefix(skip int) (string, error) {
+	pc, _, line, ok := runtime.Caller(skip)
 	if !ok {
-		tooloLog.LogError(errors.New("unable to get caller information"))
-		return
+		return "", errors.New("unable to get caller information")
 	}
 	fn := runtime.FuncForPC(pc)
 	if fn == nil {
-		tooloLog.LogError(errors.New("unable to get function information"))
-		return
+		return "", errors.New("unable to get function information")
 	}
-	pkg := strings.Split(fn.Name(), "/")
-	pkgName := strings.Join(pkg[0:len(pkg)-1], "/") + "/"
-	pkgName += strings.Split(pkg[len(pkg)-1:][0], ".")[0]
 
-	prefix := fmt.Sprintf("[%s:%d]>", pkgName, line)
-	tooloLog.LogDeep(append([]interface{}{prefix}, obj...)...)
+	return fmt.Sprintf("%s[%s:%d]>", strings.Repeat("  ", int(atomic.LoadInt32(&consoleDepth))), funcPackage(fn.Name()), line), nil
+}
+
+// funcPackage Returns import path of the package declaring the fully qualified function name
+func funcPackage(name string) string {
+	pkg := strings.Split(name, "/")
+	pkgName := strings.Join(pkg[0:len(pkg)-1], "/") + "/"
+	return pkgName + strings.Split(pkg[len(pkg)-1:][0], ".")[0]
 }
 
 // SetLogger Sets tool package logger, pass nil to disable logging
@@ -84,11 +144,18 @@ func SetLogger(l StdLogger) {
 	tooloLog = &logger{l: l}
 }
 
+// SetErrorCallerInfo Enables "[pkg:file:line]" prefix of the calling code in errors logged by verbose Try and Must
+func SetErrorCallerInfo(enabled bool) {
+	errorCallerInfo.Store(enabled)
+}
+
+var errorCallerInfo atomic.Bool
+
 // Try Probes the error and returns bool, optionally logs the message.
 func Try(err error, verbose ...bool) bool {
 	if err != nil {
 		if len(verbose) > 0 && verbose[0] {
-			tooloLog.LogError(err)
+			logVerboseError(err)
 		}
 		return true
 	}
@@ -99,12 +166,26 @@ func Try(err error, verbose ...bool) bool {
 func Must(err error, verbose ...bool) {
 	if err != nil {
 		if len(verbose) > 0 && verbose[0] {
-			tooloLog.LogError(err)
+			logVerboseError(err)
 		}
 		panic(catchableError{err})
 	}
 }
 
+// logVerboseError Logs err, prefixed with the caller position if enabled by SetErrorCallerInfo
+func logVerboseError(err error) {
+	if !errorCallerInfo.Load() || tooloLog.slog != nil {
+		tooloLog.LogError(err)
+		return
+	}
+	_, frame := externalCaller()
+	if frame.Function == "" {
+		tooloLog.LogError(err)
+		return
+	}
+	tooloLog.LogError(err, fmt.Sprintf("[%s:%s:%d]", funcPackage(frame.Function), filepath.Base(frame.File), frame.Line))
+}
+
 // Return Ignores errors, returns value.
 func Return[T any](val T, _ error) T {
 	return val
@@ -132,6 +213,40 @@ func MustReturn[T any](val T, err error) T {
 	return val
 }
 
+// MustWrap Tolerates no errors, panics with catchable err annotated by safetool.Wrap, so Catch receives the context
+func MustWrap(err error, msg string, args ...any) {
+	Must(safetool.Wrap(err, msg, args...))
+}
+
+// MustLazy Returns getter calling init once on the first use, the getter tolerates no errors of init,
+// panicking with catchable one on every call if init failed.
+func MustLazy[T any](init func() (T, error)) func() T {
+	lazy := safetool.Lazy(init)
+	return func() T {
+		return MustReturn(lazy.Get())
+	}
+}
+
+// MustWithin Tolerates no errors and no delays, panics with catchable ErrTimeout if f doesn't finish within d.
+// f is not interrupted on timeout, it keeps running in its goroutine, panics inside f are passed through as errors.
+func MustWithin(d time.Duration, f func() error) {
+	done := make(chan error, 1)
+	GoCatch(func() {
+		done <- f()
+	}, func(err error) {
+		done <- err
+	})
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case err := <-done:
+		Must(err)
+	case <-timer.C:
+		Must(fmt.Errorf("%w: not finished within %s", ErrTimeout, d))
+	}
+}
+
 // Err Returns the last argument if it is an error, otherwise nil
 func Err(args ...any) error {
 	var err error
@@ -142,7 +257,7 @@ func Err(args ...any) error {
 }
 
 // Catch Recovers from panic and callbacks with error
-// If error is not catchableError, it will panic again
+// If error is not catchableError, it will panic again with *PanicError wrapping the original value and stack
 // May be used as defer, coupled with MustReturn or Must, to override named return values
 //
 // Usage:
@@ -162,19 +277,129 @@ func Catch(fn func(err error)) {
 		return
 	}
 
-	if iamError, ok := e.(*catchableError); ok {
-		fn(iamError.Unwrap())
+	if err, ok := catchable(e); ok {
+		fn(err)
+		return
+	}
+	panic(newPanicError(e))
+}
+
+// CatchAs Catch that intercepts only errors matching T with errors.As, other catchable errors are re-raised as is
+// for outer Catch calls. May be used as defer only.
+func CatchAs[T error](fn func(err T)) {
+	e := recover()
+	if e == nil {
+		return
+	}
+
+	err, ok := catchable(e)
+	if !ok {
+		panic(newPanicError(e))
+	}
+	var target T
+	if !errors.As(err, &target) {
+		panic(e)
+	}
+	fn(target)
+}
+
+// CatchInto Catch that assigns the caught error to *errPtr, usually a named return value:
+//
+//	defer tool.CatchInto(&err)
+func CatchInto(errPtr *error) {
+	e := recover()
+	if e == nil {
+		return
+	}
+
+	if err, ok := catchable(e); ok {
+		*errPtr = err
 		return
 	}
-	panic(e)
+	panic(newPanicError(e))
+}
+
+// CatchAll Recovers from any panic and callbacks with error
+// Errors raised by Must are unwrapped, other errors are passed as is, non-error values are wrapped into *PanicError
+// May be used as defer, same as Catch
+func CatchAll(fn func(err error)) {
+	e := recover()
+	if e == nil {
+		return
+	}
+
+	if err, ok := catchable(e); ok {
+		fn(err)
+		return
+	}
+	if err, ok := e.(error); ok {
+		fn(err)
+		return
+	}
+	fn(newPanicError(e))
+}
+
+// GoCatch Runs fn in a new goroutine, any panic inside is recovered with CatchAll and passed to onErr
+// A Must panic inside a goroutine is not caught by Catch of the caller and crashes the process, so use this instead of bare go statement
+func GoCatch(fn func(), onErr func(err error)) {
+	go func() {
+		defer CatchAll(onErr)
+		fn()
+	}()
+}
+
+// newPanicError Wraps recovered value with the current stack, keeps already wrapped values intact
+func newPanicError(e any) *PanicError {
+	if pe, ok := e.(*PanicError); ok {
+		return pe
+	}
+	return &PanicError{Value: e, Stack: debug.Stack()}
+}
+
+// catchable Returns the wrapped error if e is a panic value raised by Must
+func catchable(e any) (error, bool) {
+	switch ce := e.(type) {
+	case catchableError:
+		return ce.error, true
+	case *catchableError:
+		return ce.error, true
+	}
+	return nil, false
 }
 
 // RandInt Return a random number in specified range.
 func RandInt[num constraints.Signed](min, max num) num {
-	bInt, err := rand.Int(rand.Reader, big.NewInt(int64(max-min)))
-	Must(err)
-	bInt = bInt.Add(bInt, big.NewInt(int64(min)))
-	return num(bInt.Int64())
+	return num(RandInt64(int64(min), int64(max)))
+}
+
+// RandInt64 Returns a random number in [min, max), any int64 range is supported, panics if the range is empty
+func RandInt64(min, max int64) int64 {
+	return MustReturn(safetool.RandInt64(min, max))
+}
+
+// RandUint64 Returns a random number in [min, max), any uint64 range is supported, panics if the range is empty
+func RandUint64(min, max uint64) uint64 {
+	return MustReturn(safetool.RandUint64(min, max))
+}
+
+// RandDuration Returns a random duration in [min, max), panics if the range is empty
+func RandDuration(min, max time.Duration) time.Duration {
+	return MustReturn(safetool.RandDuration(min, max))
+}
+
+// Jitter Return a random duration in range d ± d*fraction, fraction is clamped to [0, 1]
+func Jitter(d time.Duration, fraction float64) time.Duration {
+	return MustReturn(safetool.Jitter(d, fraction))
+}
+
+// UUIDv4 Returns random UUID from crypto/rand
+func UUIDv4() Varchar {
+	return Varchar(MustReturn(safetool.UUIDv4()))
+}
+
+// UUIDv7 Returns time-ordered UUID of the package clock time
+func UUIDv7() Varchar {
+	return Varchar(MustReturn(safetool.UUIDv7At(clockNow())))
 }
 
 // Ptr Return a pointer for any passed object
@@ -187,7 +412,8 @@ func In[T comparable](needle T, haystack ...T) bool {
 	return slices.Contains(haystack, needle)
 }
 
-// RetryFunc Re-runs function if error returned
+// RetryFunc Re-runs function if error returned, attempts is the number of retries, so f runs up to attempts+1 times.
+// See Retry with WithMaxAttempts or WithMaxRetries to state the intent explicitly.
 func RetryFunc[num constraints.Signed](attempts num, sleep time.Duration, f func() error) error {
 	var retryErr error
 	for {
@@ -200,8 +426,8 @@ func RetryFunc[num constraints.Signed](attempts num, sleep time.Duration, f func
 			break
 		}
 		attempts--
-		time.Sleep(sleep)
-		tooloLog.LogError(retryErr, "retrying after error")
+		clockSleep(sleep)
+		tooloLog.LogErrorAt(LevelDebug, retryErr, "retrying after error")
 	}
 	return retryErr
 }
@@ -240,18 +466,60 @@ func Objectify[T ~[]byte | ~string](in T, target any) bool {
 	return !Try(json.Unmarshal([]byte(in), target), true)
 }
 
-// Strtr Replaces all old string occurrences with new string in subject
+// Strtr Replaces all old string occurrences with new string in subject.
+// The longest key matching at a position wins, replaced text is not scanned again, see Compat for the legacy behavior.
 func Strtr(subject string, oldToNew map[string]string) string {
 	if len(oldToNew) == 0 || len(subject) == 0 {
 		return subject
 	}
+	if compatBefore(CompatV2) {
+		for old, news := range oldToNew {
+			if old == "" || old == news {
+				continue
+			}
+			subject = strings.ReplaceAll(subject, old, news)
+		}
+		return subject
+	}
+
+	pairs := make([][2]string, 0, len(oldToNew))
 	for old, news := range oldToNew {
-		if old == "" || old == news {
-			continue
+		pairs = append(pairs, [2]string{old, news})
+	}
+	return StrtrPairs(subject, pairs...)
+}
+
+// StrtrPairs Replaces old strings (pair[0]) with new ones (pair[1]) in subject in a single pass.
+// The longest old string matching at a position wins, among equal ones the first given pair wins.
+func StrtrPairs(subject string, pairs ...[2]string) string {
+	keys := make([][2]string, 0, len(pairs))
+	for _, pair := range pairs {
+		if pair[0] != "" {
+			keys = append(keys, pair)
+		}
+	}
+	if len(keys) == 0 || len(subject) == 0 {
+		return subject
+	}
+	sort.SliceStable(keys, func(i, j int) bool { return len(keys[i][0]) > len(keys[j][0]) })
+
+	var buf strings.Builder
+	for i := 0; i < len(subject); {
+		matched := false
+		for _, pair := range keys {
+			if strings.HasPrefix(subject[i:], pair[0]) {
+				buf.WriteString(pair[1])
+				i += len(pair[0])
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			buf.WriteByte(subject[i])
+			i++
 		}
-		subject = strings.ReplaceAll(subject, old, news)
 	}
-	return subject
+	return buf.String()
 }
 
 // NonZero Returns first non-zero value or zero value if all values are zero
@@ -269,6 +537,90 @@ func NonZero[T comparable](ts ...T) T {
 	return zeroValue
 }
 
+// If Returns a if cond is true, otherwise b
+func If[T any](cond bool, a, b T) T {
+	if cond {
+		return a
+	}
+	return b
+}
+
+// IfFunc Returns result of a if cond is true, otherwise result of b, only the chosen function is called
+func IfFunc[T any](cond bool, a, b func() T) T {
+	if cond {
+		return a()
+	}
+	return b()
+}
+
+// SortedKeys Returns keys of the map in ascending order
+func SortedKeys[K constraints.Ordered, V any](m map[K]V) []K {
+	return safetool.SortedKeys(m)
+}
+
+// RangeSorted Calls f for every map entry in ascending key order
+func RangeSorted[K constraints.Ordered, V any](m map[K]V, f func(K, V)) {
+	for _, key := range SortedKeys(m) {
+		f(key, m[key])
+	}
+}
+
+// IsZero Checks if value is the zero value of its type, works with non-comparable types as safetool.IsZeroAny.
+// Interface typed values are zero only when nil.
+func IsZero[T any](v T) bool {
+	if val := reflect.ValueOf(&v).Elem(); val.Kind() == reflect.Interface {
+		return val.IsNil()
+	}
+	return safetool.IsZeroAny(v)
+}
+
+// IsZeroExcept Checks if struct value is zero, ignoring the named fields
+// Nested fields are addressed with dots, e.g. "Meta.Version", pointers are dereferenced
+// Non-struct values are checked as a whole
+func IsZeroExcept(v any, fields ...string) bool {
+	return isZeroExcept(reflect.ValueOf(v), fields)
+}
+
+func isZeroExcept(val reflect.Value, fields []string) bool {
+	for val.Kind() == reflect.Pointer || val.Kind() == reflect.Interface {
+		if val.IsNil() {
+			return true
+		}
+		val = val.Elem()
+	}
+	if !val.IsValid() {
+		return true
+	}
+	if val.Kind() != reflect.Struct {
+		return val.IsZero()
+	}
+
+	ignored := map[string]bool{}
+	nested := map[string][]string{}
+	for _, field := range fields {
+		if name, rest, ok := strings.Cut(field, "."); ok {
+			nested[name] = append(nested[name], rest)
+		} else {
+			ignored[field] = true
+		}
+	}
+
+	for i := 0; i < val.NumField(); i++ {
+		name := val.Type().Field(i).Name
+		switch rest, ok := nested[name]; {
+		case ignored[name]:
+			continue
+		case ok:
+			if !isZeroExcept(val.Field(i), rest) {
+				return false
+			}
+		case !val.Field(i).IsZero():
+			return false
+		}
+	}
+	return true
+}
+
 // identifyPanic Helper function to get user-friendly call stack message.
 func identifyPanic() string {
 	var name, file string
@@ -308,45 +660,85 @@ func (s Varchar) String() string {
 	return string(s)
 }
 
-func (s *Varchar) MarshalJSON() ([]byte, error) {
-	if len(s.Bytes()) == 0 {
-		return s.Bytes(), nil
-	}
-	if res := Jsonify(s.Bytes()).Bytes(); len(res) > 0 {
-		return res, nil
-	}
-	return nil, fmt.Errorf("failed to marshal varchar")
+// MarshalJSON Implements json.Marshaler, encodes as JSON string
+func (s Varchar) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(s))
 }
 
 // Log Logs anything
 func (l *logger) Log(msgs ...any) {
+	l.LogAt(LevelInfo, msgs...)
+}
+
+// LogAt Logs anything with the given level
+func (l *logger) LogAt(level LogLevel, msgs ...any) {
+	if !level.enabled() {
+		return
+	}
+	if l.slog != nil {
+		l.logAttrs(level.slogLevel(), strings.TrimSuffix(fmt.Sprintln(msgs...), "\n"))
+		return
+	}
 	if l.l == nil {
 		return
 	}
-	l.l.Println(msgs)
+	l.l.Println(msgs...)
+}
+
+// LogText Logs text as is, keeping line breaks
+func (l *logger) LogText(text string) {
+	if l.l == nil || !LevelInfo.enabled() {
+		return
+	}
+	l.l.Println(text)
 }
 
 // LogDeep Printf version to log objects deeply
 func (l *logger) LogDeep(obj ...any) {
-	if l.l == nil {
+	if l.l == nil || !LevelInfo.enabled() {
 		return
 	}
+	l.l.Println(deepString(obj...))
+}
+
+// deepString Joins SafeSprint of objects with spaces, escaping line breaks
+func deepString(obj ...any) string {
 	var buf strings.Builder
 	for _, subj := range obj {
-		buf.WriteString(fmt.Sprintf("%+v ", subj))
+		buf.WriteString(SafeSprint(subj) + " ")
 	}
-	str := buf.String()[:buf.Len()-1]
-	str = strings.ReplaceAll(strings.ReplaceAll(str, "\r", "\\r"), "\n", "\\n")
-	l.l.Println(str)
+	str := strings.TrimSuffix(buf.String(), " ")
+	return strings.ReplaceAll(strings.ReplaceAll(str, "\r", "\\r"), "\n", "\\n")
 }
 
 // LogError Loose function to log error
 func (l *logger) LogError(err error, msgs ...string) {
+	l.LogErrorAt(LevelError, err, msgs...)
+}
+
+// LogErrorAt Logs error with the given level, LogRus loggers get Debugln and Warnln calls where supported
+func (l *logger) LogErrorAt(level LogLevel, err error, msgs ...string) {
+	if !level.enabled() {
+		return
+	}
+	if l.slog != nil {
+		l.logAttrs(level.slogLevel(), strings.Join(msgs, ": "), slog.Any("error", JSONError{Err: err}))
+		return
+	}
 	if l.l == nil {
 		return
 	}
 	if isrus, ok := l.l.(LogRus); ok {
-		isrus.WithError(err).Errorln(strings.Join(msgs, ": "))
+		entry, msg := isrus.WithError(err), strings.Join(msgs, ": ")
+		if debug, ok := entry.(interface{ Debugln(...any) }); ok && level == LevelDebug {
+			debug.Debugln(msg)
+			return
+		}
+		if warn, ok := entry.(interface{ Warnln(...any) }); ok && level == LevelWarn {
+			warn.Warnln(msg)
+			return
+		}
+		entry.Errorln(msg)
 		return
 	}
 	if len(msgs) > 0 {
@@ -378,53 +770,89 @@ func ExecTemplate(templateText string, templateVars any) string {
 	return buf.String()
 }
 
-// ConvertSlice Return a new slice as `[]dstTypedValue.(type)` cast from the `srcSlice`
+// ConvertSlice Return a new slice as `[]dstTypedValue.(type)` cast from the `srcSlice`.
+// Elements are converted with safetool.ConvertStruct rules, panics if an element is not convertible.
 func ConvertSlice[T any, Y any](srcSlice []T, destTypedValue Y) []Y {
-	srcReflectType := reflect.TypeOf(srcSlice)
-	if srcReflectType.Kind() != reflect.Slice {
-		panic("srcSlice is not a slice")
+	if srcSlice == nil {
+		return nil
+	}
+	destSlice := make([]Y, len(srcSlice))
+	for i := range srcSlice {
+		destSlice[i] = MustReturn(safetool.ConvertStruct[T, Y](srcSlice[i]))
 	}
+	return destSlice
+}
+
+// WithStringCoercion Converts between strings and bools or numbers with strconv instead of failing,
+// integers are formatted as numbers rather than converted to rune strings
+func WithStringCoercion() Option[safetool.ConvertConfig] {
+	return func(c *safetool.ConvertConfig) { c.StringCoercion = true }
+}
+
+// WithStrconv Enables string coercion with integers in base, floats formatted as in strconv.FormatFloat
+func WithStrconv(base int, floatFormat byte, floatPrecision int) Option[safetool.ConvertConfig] {
+	return func(c *safetool.ConvertConfig) {
+		c.StringCoercion, c.IntBase, c.FloatFormat, c.FloatPrecision = true, base, floatFormat, floatPrecision
+	}
+}
+
+// ConvertSliceOpts Same as ConvertSlice, with conversion rules adjusted by options
+func ConvertSliceOpts[T any, Y any](srcSlice []T, destTypedValue Y, opts ...Option[safetool.ConvertConfig]) []Y {
+	cfg := MustReturn(Build(safetool.ConvertConfig{}, opts...))
 	if srcSlice == nil {
 		return nil
-	} else if len(srcSlice) == 0 {
-		return []Y{}
 	}
-	destType := reflect.TypeOf(destTypedValue)
-	destSlice := reflect.MakeSlice(reflect.SliceOf(destType), len(srcSlice), len(srcSlice))
+	destSlice := make([]Y, len(srcSlice))
 	for i := range srcSlice {
-		srcVal := reflect.Indirect(reflect.ValueOf(srcSlice[i]))
-		destVal := reflect.New(destType).Elem()
-		switch {
-		case srcVal.Type().ConvertibleTo(destType):
-			destVal = srcVal.Convert(destType)
-		case srcVal.Type().AssignableTo(destType):
-			destVal = srcVal
-		default:
-			for j := 0; j < srcVal.NumField(); j++ {
-				srcField := srcVal.Type().Field(j)
-				destField := destVal.FieldByName(srcField.Name)
-				if destField.IsValid() && srcField.Type.AssignableTo(destField.Type()) {
-					destField.Set(srcVal.Field(j))
-				}
-			}
-		}
-		destSlice.Index(i).Set(destVal)
+		destSlice[i] = MustReturn(safetool.ConvertStructWith[T, Y](srcSlice[i], cfg))
 	}
-	return destSlice.Interface().([]Y)
+	return destSlice
 }
 
-// findRootCaller Finds the root caller filepath of the application
-func findRootCaller() string {
-	const MaxDepth = 32
+// MapSlice Returns results of fn for every item, panics on error
+func MapSlice[T, R any](items []T, fn func(T) (R, error)) []R {
+	return MustReturn(safetool.MapSlice(items, fn))
+}
 
-	for i := 0; i < MaxDepth; i++ {
-		_, file, _, ok := runtime.Caller(i)
-		if !ok {
-			break
-		}
-		if i == MaxDepth-1 || !strings.Contains(file, "runtime/") {
-			return file
+// FilterSlice Returns items fn reported true for, panics on error
+func FilterSlice[T any](items []T, fn func(T) (bool, error)) []T {
+	return MustReturn(safetool.FilterSlice(items, fn))
+}
+
+// ReduceSlice Folds items into acc with fn, panics on error
+func ReduceSlice[T, R any](items []T, acc R, fn func(R, T) (R, error)) R {
+	return MustReturn(safetool.ReduceSlice(items, acc, fn))
+}
+
+// ParseSlice Parses every string of the slice with parse, returns the first error annotated with its index
+func ParseSlice[T any](in []string, parse func(string) (T, error)) ([]T, error) {
+	if in == nil {
+		return nil, nil
+	}
+	out := make([]T, len(in))
+	for i, s := range in {
+		v, err := parse(s)
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %w", i, err)
 		}
+		out[i] = v
+	}
+	return out, nil
+}
+
+// AtoiSlice Converts slice of decimal strings to ints
+func AtoiSlice(in []string) ([]int, error) {
+	return ParseSlice(in, strconv.Atoi)
+}
+
+// ItoaSlice Converts slice of ints to decimal strings
+func ItoaSlice(in []int) []string {
+	if in == nil {
+		return nil
+	}
+	out := make([]string, len(in))
+	for i, n := range in {
+		out[i] = strconv.Itoa(n)
 	}
-	return ""
+	return out
 }
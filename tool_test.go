@@ -7,6 +7,7 @@ import (
 	"reflect"
 	"strconv"
 	"testing"
+	"time"
 )
 
 type (
@@ -63,17 +64,17 @@ func (s *ToolTestSuite) TestIn() {
 func (s *ToolTestSuite) TestConsole() {
 	s.Run("1", func() {
 		Console("123", "456", "789")
-		s.Equal("[github.com/iamwavecut/tool:65]> 123 456 789\n", testLog.buf)
+		s.Equal("[github.com/iamwavecut/tool:66]> 123 456 789\n", testLog.buf)
 	})
 	s.Run("2", func() {
 		testLog.buf = ""
 		Console(struct{ int }{123})
-		s.Equal("[github.com/iamwavecut/tool:70]> {int:123}\n", testLog.buf)
+		s.Equal("[github.com/iamwavecut/tool:71]> {int:123}\n", testLog.buf)
 	})
 	s.Run("3", func() {
 		testLog.buf = ""
 		Console(nil)
-		s.Equal("[github.com/iamwavecut/tool:75]> <nil>\n", testLog.buf)
+		s.Equal("[github.com/iamwavecut/tool:76]> <nil>\n", testLog.buf)
 	})
 }
 
@@ -94,6 +95,45 @@ func (s *ToolTestSuite) TestNonZero() {
 	})
 }
 
+func (s *ToolTestSuite) TestIsZero() {
+	s.True(IsZero(""))
+	s.False(IsZero("hi"))
+	s.True(IsZero(struct{ i int }{}))
+	s.False(IsZero(Ptr(0)))
+	s.True(IsZero(struct{ Hosts []string }{}))
+	s.False(IsZero(struct{ Hosts []string }{Hosts: []string{}}))
+	s.True(IsZero(error(nil)))
+	s.False(IsZero[error](ErrTimeout))
+}
+
+func (s *ToolTestSuite) TestIsZeroExcept() {
+	type meta struct {
+		Version int
+		Author  string
+	}
+	type doc struct {
+		Meta  *meta
+		Tags  []string
+		title string
+	}
+
+	s.True(IsZeroExcept(doc{}))
+	s.True(IsZeroExcept((*doc)(nil)))
+	s.True(IsZeroExcept(nil))
+	s.True(IsZeroExcept(0))
+	s.False(IsZeroExcept("hi", "ignored"))
+
+	withMeta := doc{Meta: &meta{Version: 2, Author: "me"}}
+	s.False(IsZeroExcept(withMeta))
+	s.True(IsZeroExcept(withMeta, "Meta"))
+	s.True(IsZeroExcept(&withMeta, "Meta.Version", "Meta.Author"))
+	s.False(IsZeroExcept(withMeta, "Meta.Version"))
+
+	s.False(IsZeroExcept(doc{Tags: []string{}}, "Meta"))
+	s.False(IsZeroExcept(doc{title: "unexported"}))
+	s.True(IsZeroExcept(doc{title: "unexported"}, "title"))
+}
+
 func (s *ToolTestSuite) TestJsonify() {
 	s.Run("string", func() {
 		res := Jsonify([]string{"oh", "hi", "there"})
@@ -188,9 +228,64 @@ func (s *ToolTestSuite) TestMust() {
 	})
 }
 
+func (s *ToolTestSuite) TestMustWithin() {
+	s.Run("in time", func() {
+		s.NotPanics(func() {
+			MustWithin(time.Second, func() error { return nil })
+		})
+	})
+	s.Run("error", func() {
+		var err error
+		func() {
+			defer Catch(func(caught error) { err = caught })
+			MustWithin(time.Second, func() error { return errors.New("migration failed") })
+		}()
+		s.EqualError(err, "migration failed")
+	})
+	s.Run("timeout", func() {
+		release := make(chan struct{})
+		defer close(release)
+		var err error
+		func() {
+			defer Catch(func(caught error) { err = caught })
+			MustWithin(time.Millisecond, func() error {
+				<-release
+				return nil
+			})
+		}()
+		s.ErrorIs(err, ErrTimeout)
+	})
+	s.Run("panic", func() {
+		var err error
+		func() {
+			defer Catch(func(caught error) { err = caught })
+			MustWithin(time.Second, func() error { panic("boom") })
+		}()
+		var panicErr *PanicError
+		s.ErrorAs(err, &panicErr)
+	})
+}
+
 // TestRandInt is non-deterministic and hollow, but it exists for the sake of the coverage
 func (s *ToolTestSuite) TestRandInt() {
 	s.Contains([]int{1, 2, 3, 4, 5}, RandInt(1, 5))
+	s.NotPanics(func() { RandInt64(-1<<63, 1<<63-1) })
+	s.Equal(uint64(1<<64-2), RandUint64(1<<64-2, 1<<64-1))
+	d := RandDuration(time.Second, 2*time.Second)
+	s.True(d >= time.Second && d < 2*time.Second)
+	s.Panics(func() { RandDuration(time.Second, time.Second) })
+}
+
+func (s *ToolTestSuite) TestJitter() {
+	for i := 0; i < 100; i++ {
+		d := Jitter(time.Second, 0.2)
+		s.GreaterOrEqual(d, 800*time.Millisecond)
+		s.LessOrEqual(d, 1200*time.Millisecond)
+	}
+	s.Equal(time.Second, Jitter(time.Second, 0))
+	s.Equal(time.Second, Jitter(time.Second, -1))
+	s.Equal(time.Duration(0), Jitter(0, 0.5))
+	s.LessOrEqual(Jitter(time.Second, 5), 2*time.Second)
 }
 
 func (s *ToolTestSuite) TestPtr() {
@@ -421,10 +516,24 @@ func (s *ToolTestSuite) TestCatch() {
 		panic(&catchableError{errors.New("catchable error")})
 	})
 
+	s.Run("must error", func() {
+		var err error
+		func() {
+			defer Catch(func(caught error) {
+				err = caught
+			})
+			Must(errors.New("must error"))
+		}()
+		s.EqualError(err, "must error")
+	})
+
 	s.Run("uncatchable error", func() {
 		defer func() {
 			if r := recover(); r != nil {
-				s.EqualError(r.(error), "uncatchable error")
+				var panicErr *PanicError
+				s.Require().ErrorAs(r.(error), &panicErr)
+				s.EqualError(panicErr.Unwrap(), "uncatchable error")
+				s.Contains(string(panicErr.Stack), "TestCatch")
 			} else {
 				s.Fail("Expected a panic")
 			}
@@ -436,6 +545,60 @@ func (s *ToolTestSuite) TestCatch() {
 
 		panic(errors.New("uncatchable error"))
 	})
+
+	s.Run("nested catch keeps original stack", func() {
+		var stack []byte
+		defer func() {
+			r := recover()
+			s.Require().IsType(&PanicError{}, r)
+			s.Equal("value", r.(*PanicError).Value)
+			s.Equal(stack, r.(*PanicError).Stack)
+		}()
+
+		defer Catch(func(error) {})
+		func() {
+			defer func() {
+				r := recover()
+				stack = r.(*PanicError).Stack
+				panic(r)
+			}()
+			defer Catch(func(error) {})
+			panic("value")
+		}()
+	})
+}
+
+func (s *ToolTestSuite) TestCatchAll() {
+	for _, tc := range []struct {
+		name     string
+		panicVal any
+		expected string
+	}{
+		{name: "must", panicVal: catchableError{errors.New("must error")}, expected: "must error"},
+		{name: "error", panicVal: errors.New("plain error"), expected: "plain error"},
+		{name: "value", panicVal: 42, expected: "panic: 42"},
+	} {
+		s.Run(tc.name, func() {
+			var err error
+			func() {
+				defer CatchAll(func(caught error) {
+					err = caught
+				})
+				panic(tc.panicVal)
+			}()
+			s.EqualError(err, tc.expected)
+		})
+	}
+}
+
+func (s *ToolTestSuite) TestGoCatch() {
+	errs := make(chan error, 1)
+	GoCatch(func() {
+		Must(errors.New("goroutine error"))
+	}, func(err error) {
+		errs <- err
+	})
+	s.EqualError(<-errs, "goroutine error")
 }
 
 func (s *ToolTestSuite) TestConvertSlice() {
@@ -505,4 +668,245 @@ func (s *ToolTestSuite) TestConvertSlice() {
 		s.Equal(len(resultInterface.([]float64)), len(input), "result slice size should match input slice size")
 		s.Equal(result, expectedOutput, "slice conversion not as expected")
 	})
+
+	s.Run("struct conversion", func() {
+		type inner struct{ N int }
+		type src struct {
+			Name  string `convert:"Title"`
+			Inner *inner
+		}
+		type dst struct {
+			Title string
+			Inner struct{ N int64 }
+		}
+		result := ConvertSlice([]src{{Name: "a", Inner: &inner{N: 1}}, {Name: "b"}}, dst{})
+		s.Equal([]dst{{Title: "a", Inner: struct{ N int64 }{1}}, {Title: "b"}}, result)
+		s.Panics(func() { ConvertSlice([]string{"x"}, dst{}) })
+	})
+
+	s.Run("string coercion", func() {
+		s.Equal([]string{"A"}, ConvertSlice([]int{65}, ""), "rune conversion is kept without coercion")
+		s.Equal([]string{"65", "-1"}, ConvertSliceOpts([]int{65, -1}, "", WithStringCoercion()))
+		s.Equal([]int{1, 2}, ConvertSliceOpts([]string{"1", "2"}, 0, WithStringCoercion()))
+		s.Equal([]string{"ff"}, ConvertSliceOpts([]uint8{255}, "", WithStrconv(16, 0, 0)))
+		s.Equal([]string{"1.50"}, ConvertSliceOpts([]float64{1.5}, "", WithStrconv(10, 'f', 2)))
+		s.Panics(func() { ConvertSliceOpts([]string{"x"}, 0, WithStringCoercion()) })
+	})
+}
+
+func (s *ToolTestSuite) TestParseSlice() {
+	s.Run("floats", func() {
+		res, err := ParseSlice([]string{"1.5", "2"}, func(s string) (float64, error) {
+			return strconv.ParseFloat(s, 64)
+		})
+		s.NoError(err)
+		s.Equal([]float64{1.5, 2}, res)
+	})
+	s.Run("nil", func() {
+		res, err := ParseSlice(nil, strconv.ParseBool)
+		s.NoError(err)
+		s.Nil(res)
+	})
+}
+
+func (s *ToolTestSuite) TestAtoiSlice() {
+	res, err := AtoiSlice([]string{"1", "-2", "30"})
+	s.NoError(err)
+	s.Equal([]int{1, -2, 30}, res)
+
+	_, err = AtoiSlice([]string{"1", "two"})
+	s.ErrorContains(err, "item 1")
+	s.ErrorIs(err, strconv.ErrSyntax)
+}
+
+func (s *ToolTestSuite) TestItoaSlice() {
+	s.Equal([]string{"1", "-2", "30"}, ItoaSlice([]int{1, -2, 30}))
+	s.Equal([]string{}, ItoaSlice([]int{}))
+	s.Nil(ItoaSlice(nil))
+}
+
+func (s *ToolTestSuite) TestConsoleGroup() {
+	closeOuter := ConsoleGroup("outer")
+	closeInner := ConsoleGroup("inner")
+	Console("step")
+	closeInner()
+	closeInner()
+	closeOuter()
+	Console("after")
+
+	s.Regexp(`^\[github.com/iamwavecut/tool:\d+\]> outer
+  \[github.com/iamwavecut/tool:\d+\]> inner
+    \[github.com/iamwavecut/tool:\d+\]> step
+  \[github.com/iamwavecut/tool:\d+\]> inner done in \S+
+\[github.com/iamwavecut/tool:\d+\]> outer done in \S+
+\[github.com/iamwavecut/tool:\d+\]> after
+$`, testLog.buf)
+}
+
+func (s *ToolTestSuite) TestSetErrorCallerInfo() {
+	SetErrorCallerInfo(true)
+	defer SetErrorCallerInfo(false)
+
+	s.True(Try(fmt.Errorf("verbose error"), true))
+	s.Regexp(`^\[github.com/iamwavecut/tool:tool_test.go:\d+\]: verbose error\n$`, testLog.buf)
+
+	testLog.buf = ""
+	s.Panics(func() { Must(fmt.Errorf("must error"), true) })
+	s.Regexp(`^\[github.com/iamwavecut/tool:tool_test.go:\d+\]: must error\n$`, testLog.buf)
+
+	testLog.buf = ""
+	SetErrorCallerInfo(false)
+	s.True(Try(fmt.Errorf("verbose error"), true))
+	s.Equal("verbose error\n", testLog.buf)
+}
+
+func (s *ToolTestSuite) TestSortedKeys() {
+	m := map[string]int{"b": 2, "c": 3, "a": 1}
+	s.Equal([]string{"a", "b", "c"}, SortedKeys(m))
+	s.Empty(SortedKeys(map[int]bool(nil)))
+
+	var visited []string
+	RangeSorted(m, func(k string, v int) { visited = append(visited, fmt.Sprint(k, v)) })
+	s.Equal([]string{"a1", "b2", "c3"}, visited)
+}
+
+func (s *ToolTestSuite) TestSliceHelpers() {
+	s.Equal([]int{1, 2}, MapSlice([]string{"1", "2"}, strconv.Atoi))
+	s.Panics(func() { MapSlice([]string{"x"}, strconv.Atoi) })
+	s.Equal([]int{3}, FilterSlice([]int{1, 3}, func(n int) (bool, error) { return n > 1, nil }))
+	s.Equal(6, ReduceSlice([]int{1, 2, 3}, 0, func(acc, n int) (int, error) { return acc + n, nil }))
+	s.Panics(func() {
+		ReduceSlice([]int{1}, 0, func(int, int) (int, error) { return 0, fmt.Errorf("fail") })
+	})
+}
+
+func (s *ToolTestSuite) TestIf() {
+	s.Equal("yes", If(true, "yes", "no"))
+	s.Equal("no", If(false, "yes", "no"))
+
+	called := false
+	s.Equal(2, IfFunc(false, func() int { called = true; return 1 }, func() int { return 2 }))
+	s.False(called)
+	s.Equal(1, IfFunc(true, func() int { return 1 }, func() int { panic("not called") }))
+}
+
+func (s *ToolTestSuite) TestUUID() {
+	s.Regexp(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`, UUIDv4().String())
+
+	SetClock(NewManualClock(time.UnixMilli(0x018f3461ec00)))
+	defer SetClock(nil)
+	s.Regexp(`^018f3461-ec00-7[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`, UUIDv7().String())
+}
+
+func (s *ToolTestSuite) TestMustLazy() {
+	calls := 0
+	get := MustLazy(func() (int, error) {
+		calls++
+		return 7, nil
+	})
+	s.Equal(7, get())
+	s.Equal(7, get())
+	s.Equal(1, calls)
+
+	errBoom := errors.New("boom")
+	failing := MustLazy(func() (int, error) { return 0, errBoom })
+	for i := 0; i < 2; i++ {
+		func() {
+			defer Catch(func(err error) { s.ErrorIs(err, errBoom) })
+			failing()
+			s.Fail("must panic")
+		}()
+	}
+}
+
+func (s *ToolTestSuite) TestStrtrOverlapping() {
+	pairs := map[string]string{"a": "b", "b": "a", "ab": "X", "abc": "Y"}
+	for i := 0; i < 20; i++ {
+		s.Equal("YXbX", Strtr("abcabaab", pairs))
+	}
+	s.Equal("hello world", Strtr("hi all", map[string]string{"hi": "hello", "all": "world", "hello": "bye"}))
+
+	defer Compat(CompatLatest)
+	Compat(CompatV1)
+	s.Equal("bar", Strtr("foo", map[string]string{"foo": "bar"}))
+	s.Contains([]string{"hello all", "bye all"}, Strtr("hi all", map[string]string{"hi": "hello", "hello": "bye"}))
+}
+
+func (s *ToolTestSuite) TestStrtrPairs() {
+	s.Equal("YXbX", StrtrPairs("abcabaab", [2]string{"a", "b"}, [2]string{"ab", "X"}, [2]string{"abc", "Y"}))
+	s.Equal("1-1", StrtrPairs("a-a", [2]string{"a", "1"}, [2]string{"a", "2"}))
+	s.Equal("2-2", StrtrPairs("a-a", [2]string{"a", "2"}, [2]string{"a", "1"}))
+	s.Equal("ab", StrtrPairs("ab", [2]string{"", "x"}))
+	s.Equal("ab", StrtrPairs("ab"))
+	s.Empty(StrtrPairs("", [2]string{"a", "b"}))
+}
+
+func (s *ToolTestSuite) TestMustWrap() {
+	s.NotPanics(func() { MustWrap(nil, "loading %d", 1) })
+
+	errBoom := errors.New("boom")
+	var caught error
+	func() {
+		defer Catch(func(err error) { caught = err })
+		MustWrap(errBoom, "loading %d", 1)
+	}()
+	s.EqualError(caught, "loading 1: boom")
+	s.ErrorIs(caught, errBoom)
+}
+
+type catchAsError struct{ code int }
+
+func (e *catchAsError) Error() string { return "code " + strconv.Itoa(e.code) }
+
+func (s *ToolTestSuite) TestCatchAs() {
+	var caught *catchAsError
+	func() {
+		defer CatchAs(func(err *catchAsError) { caught = err })
+		Must(fmt.Errorf("wrapped: %w", &catchAsError{code: 42}))
+	}()
+	s.Require().NotNil(caught)
+	s.Equal(42, caught.code)
+
+	errOther := errors.New("other")
+	var outer error
+	func() {
+		defer Catch(func(err error) { outer = err })
+		func() {
+			defer CatchAs(func(*catchAsError) { s.Fail("must not catch") })
+			Must(errOther)
+		}()
+	}()
+	s.ErrorIs(outer, errOther)
+
+	s.PanicsWithValue("foreign", func() {
+		defer func() {
+			if pe, ok := recover().(*PanicError); ok {
+				panic(pe.Value)
+			}
+		}()
+		defer CatchAs(func(*catchAsError) {})
+		panic("foreign")
+	})
+}
+
+func (s *ToolTestSuite) TestCatchInto() {
+	errBoom := errors.New("boom")
+	run := func(fail bool) (res int, err error) {
+		defer CatchInto(&err)
+		if fail {
+			Must(errBoom)
+		}
+		return 1, nil
+	}
+	res, err := run(false)
+	s.NoError(err)
+	s.Equal(1, res)
+	_, err = run(true)
+	s.ErrorIs(err, errBoom)
+
+	s.Panics(func() {
+		var err error
+		defer CatchInto(&err)
+		panic("foreign")
+	})
 }
@@ -0,0 +1,65 @@
+package tool
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/iamwavecut/tool/safetool"
+)
+
+// TxRetries Number of WithTx retries after a serialization failure
+var TxRetries = 3
+
+// TxBackoff Delay policy between WithTx retries
+var TxBackoff = safetool.ExponentialWithJitter(10*time.Millisecond, time.Second, 0.2)
+
+// WithTx Runs fn in a transaction, commits if fn returns nil, rolls back otherwise.
+// Errors raised by Must inside fn are caught and returned, other panics roll back and propagate.
+// The whole transaction is retried up to TxRetries times while it fails with IsSerializationFailure errors,
+// so fn must not have side effects outside tx.
+func WithTx(ctx context.Context, db *sql.DB, opts *sql.TxOptions, fn func(tx *sql.Tx) error) error {
+	return safetool.RetryIf(ctx, TxRetries, TxBackoff, func(ctx context.Context) error {
+		return runTx(ctx, db, opts, fn)
+	}, IsSerializationFailure)
+}
+
+// IsSerializationFailure Reports whether err is a serialization failure or deadlock (SQLSTATE 40001, 40P01),
+// recognized by SQLState() method of driver errors, like pgx and pq ones
+func IsSerializationFailure(err error) bool {
+	var state interface{ SQLState() string }
+	if !errors.As(err, &state) {
+		return false
+	}
+	code := state.SQLState()
+	return code == "40001" || code == "40P01"
+}
+
+func runTx(ctx context.Context, db *sql.DB, opts *sql.TxOptions, fn func(tx *sql.Tx) error) (err error) {
+	tx, err := db.BeginTx(ctx, opts)
+	if err != nil {
+		return err
+	}
+	committed := false
+	defer func() {
+		if committed {
+			return
+		}
+		if rbErr := tx.Rollback(); rbErr != nil && !errors.Is(rbErr, sql.ErrTxDone) && err != nil {
+			err = errors.Join(err, rbErr)
+		}
+	}()
+	defer Catch(func(caught error) {
+		err = caught
+	})
+
+	if err = fn(tx); err != nil {
+		return err
+	}
+	if err = tx.Commit(); err != nil {
+		return err
+	}
+	committed = true
+	return nil
+}
@@ -0,0 +1,121 @@
+package tool
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"sync"
+
+	"github.com/iamwavecut/tool/safetool"
+)
+
+type (
+	txTestDriver struct {
+		mu         sync.Mutex
+		commits    int
+		rollbacks  int
+		commitErrs []error
+	}
+	txTestConn struct{ d *txTestDriver }
+	txTestTx   struct{ d *txTestDriver }
+
+	txSQLStateError string
+)
+
+func (e txSQLStateError) Error() string    { return "sqlstate " + string(e) }
+func (e txSQLStateError) SQLState() string { return string(e) }
+
+func (d *txTestDriver) Open(string) (driver.Conn, error) { return txTestConn{d}, nil }
+
+func (d *txTestDriver) reset(commitErrs ...error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.commits, d.rollbacks, d.commitErrs = 0, 0, commitErrs
+}
+
+func (c txTestConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (c txTestConn) Close() error                        { return nil }
+func (c txTestConn) Begin() (driver.Tx, error)           { return txTestTx(c), nil }
+
+func (t txTestTx) Commit() error {
+	t.d.mu.Lock()
+	defer t.d.mu.Unlock()
+	t.d.commits++
+	if len(t.d.commitErrs) > 0 {
+		err := t.d.commitErrs[0]
+		t.d.commitErrs = t.d.commitErrs[1:]
+		return err
+	}
+	return nil
+}
+
+func (t txTestTx) Rollback() error {
+	t.d.mu.Lock()
+	defer t.d.mu.Unlock()
+	t.d.rollbacks++
+	return nil
+}
+
+var txDriver = &txTestDriver{}
+
+func init() {
+	sql.Register("tool-tx-test", txDriver)
+}
+
+func (s *ToolTestSuite) TestWithTx() {
+	backoff := TxBackoff
+	TxBackoff = safetool.Constant(0)
+	defer func() { TxBackoff = backoff }()
+
+	db := MustReturn(sql.Open("tool-tx-test", ""))
+	defer db.Close()
+	ctx := context.Background()
+	noop := func(*sql.Tx) error { return nil }
+
+	s.Run("commit", func() {
+		txDriver.reset()
+		s.NoError(WithTx(ctx, db, nil, noop))
+		s.Equal(1, txDriver.commits)
+		s.Zero(txDriver.rollbacks)
+	})
+
+	s.Run("rollback on error", func() {
+		txDriver.reset()
+		errFailed := errors.New("failed")
+		s.ErrorIs(WithTx(ctx, db, nil, func(*sql.Tx) error { return errFailed }), errFailed)
+		s.Zero(txDriver.commits)
+		s.Equal(1, txDriver.rollbacks)
+	})
+
+	s.Run("rollback on Must", func() {
+		txDriver.reset()
+		err := WithTx(ctx, db, nil, func(*sql.Tx) error {
+			Must(errors.New("must failed"))
+			return nil
+		})
+		s.EqualError(err, "must failed")
+		s.Equal(1, txDriver.rollbacks)
+	})
+
+	s.Run("rollback on panic", func() {
+		txDriver.reset()
+		s.Panics(func() { _ = WithTx(ctx, db, nil, func(*sql.Tx) error { panic("boom") }) })
+		s.Equal(1, txDriver.rollbacks)
+	})
+
+	s.Run("retry serialization failure", func() {
+		txDriver.reset(txSQLStateError("40001"), txSQLStateError("40P01"))
+		calls := 0
+		s.NoError(WithTx(ctx, db, nil, func(*sql.Tx) error { calls++; return nil }))
+		s.Equal(3, calls)
+		s.Equal(3, txDriver.commits)
+
+		txDriver.reset(txSQLStateError("23505"))
+		calls = 0
+		err := WithTx(ctx, db, nil, func(*sql.Tx) error { calls++; return nil })
+		s.ErrorIs(err, txSQLStateError("23505"))
+		s.Equal(1, calls)
+		s.False(IsSerializationFailure(err))
+	})
+}
@@ -0,0 +1,122 @@
+package tool
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+type (
+	// Union Tagged union holding one of the types registered for T with RegisterUnion, usually T is an interface.
+	// Serialized as {"type": "<name>", "payload": <value>}, nil Value as null.
+	Union[T any] struct {
+		Value T
+	}
+
+	unionEnvelope struct {
+		Type    string          `json:"type"`
+		Payload json.RawMessage `json:"payload"`
+	}
+
+	unionVariants struct {
+		byName map[string]reflect.Type
+		byType map[reflect.Type]string
+	}
+)
+
+var (
+	unionsMu sync.RWMutex
+	unions   = map[reflect.Type]*unionVariants{}
+)
+
+// RegisterUnion Registers V as a variant of Union[T] serialized under name, meant to be called from init.
+// Panics if V doesn't implement T, or name or V are already registered for T.
+func RegisterUnion[T, V any](name string) {
+	unionType, variant := reflect.TypeOf((*T)(nil)).Elem(), reflect.TypeOf((*V)(nil)).Elem()
+	if !variant.AssignableTo(unionType) {
+		panic(fmt.Sprintf("union %s: %s is not assignable", unionType, variant))
+	}
+
+	unionsMu.Lock()
+	defer unionsMu.Unlock()
+	variants, ok := unions[unionType]
+	if !ok {
+		variants = &unionVariants{byName: map[string]reflect.Type{}, byType: map[reflect.Type]string{}}
+		unions[unionType] = variants
+	}
+	if _, ok := variants.byName[name]; ok {
+		panic(fmt.Sprintf("union %s: variant %q is already registered", unionType, name))
+	}
+	if _, ok := variants.byType[variant]; ok {
+		panic(fmt.Sprintf("union %s: %s is already registered", unionType, variant))
+	}
+	variants.byName[name] = variant
+	variants.byType[variant] = name
+}
+
+// MarshalJSON Implements json.Marshaler
+func (u Union[T]) MarshalJSON() ([]byte, error) {
+	val := reflect.ValueOf(&u.Value).Elem()
+	if val.Kind() == reflect.Interface {
+		if val.IsNil() {
+			return []byte("null"), nil
+		}
+		val = val.Elem()
+	}
+
+	unionType := reflect.TypeOf((*T)(nil)).Elem()
+	name, ok := unionVariant(unionType, func(v *unionVariants) (string, bool) {
+		name, ok := v.byType[val.Type()]
+		return name, ok
+	})
+	if !ok {
+		return nil, fmt.Errorf("union %s: %s is not registered", unionType, val.Type())
+	}
+	payload, err := json.Marshal(val.Interface())
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(unionEnvelope{Type: name, Payload: payload})
+}
+
+// UnmarshalJSON Implements json.Unmarshaler, returns ErrUnknownEnvelope for unregistered type names
+func (u *Union[T]) UnmarshalJSON(b []byte) error {
+	var envelope *unionEnvelope
+	if err := json.Unmarshal(b, &envelope); err != nil {
+		return err
+	}
+	if envelope == nil {
+		var zero T
+		u.Value = zero
+		return nil
+	}
+
+	unionType := reflect.TypeOf((*T)(nil)).Elem()
+	variant, ok := unionVariant(unionType, func(v *unionVariants) (reflect.Type, bool) {
+		variant, ok := v.byName[envelope.Type]
+		return variant, ok
+	})
+	if !ok {
+		return fmt.Errorf("%w: %q of union %s", ErrUnknownEnvelope, envelope.Type, unionType)
+	}
+
+	target := reflect.New(variant)
+	if len(envelope.Payload) > 0 {
+		if err := json.Unmarshal(envelope.Payload, target.Interface()); err != nil {
+			return fmt.Errorf("union %s variant %q: %w", unionType, envelope.Type, err)
+		}
+	}
+	reflect.ValueOf(&u.Value).Elem().Set(target.Elem())
+	return nil
+}
+
+func unionVariant[R any](unionType reflect.Type, lookup func(*unionVariants) (R, bool)) (R, bool) {
+	unionsMu.RLock()
+	defer unionsMu.RUnlock()
+	if variants, ok := unions[unionType]; ok {
+		return lookup(variants)
+	}
+	var zero R
+	return zero, false
+}
@@ -0,0 +1,60 @@
+package tool
+
+import (
+	"encoding/json"
+)
+
+type (
+	unionShape interface{ area() float64 }
+
+	unionSquare struct {
+		Side float64 `json:"side"`
+	}
+
+	unionCircle struct {
+		R float64 `json:"r"`
+	}
+)
+
+func (s unionSquare) area() float64  { return s.Side * s.Side }
+func (c *unionCircle) area() float64 { return 3 * c.R * c.R }
+
+func init() {
+	RegisterUnion[unionShape, unionSquare]("square")
+	RegisterUnion[unionShape, *unionCircle]("circle")
+}
+
+func (s *ToolTestSuite) TestUnion() {
+	s.Run("round trip", func() {
+		in := []Union[unionShape]{{unionSquare{Side: 2}}, {&unionCircle{R: 1}}, {}}
+		b, err := json.Marshal(in)
+		s.Require().NoError(err)
+		s.JSONEq(`[{"type":"square","payload":{"side":2}},{"type":"circle","payload":{"r":1}},null]`, string(b))
+
+		var out []Union[unionShape]
+		s.Require().NoError(json.Unmarshal(b, &out))
+		s.Equal(in, out)
+		s.Equal(4.0, out[0].Value.area())
+	})
+
+	s.Run("bus payload", func() {
+		bus, err := NewBus[Union[unionShape]](&MemoryStore[Union[unionShape]]{})
+		s.Require().NoError(err)
+		e, err := bus.Publish(Union[unionShape]{unionSquare{Side: 1}})
+		s.NoError(err)
+		s.JSONEq(`{"type":"square","payload":{"side":1}}`, Jsonify(e.Payload).String())
+	})
+
+	s.Run("errors", func() {
+		var u Union[unionShape]
+		s.ErrorIs(json.Unmarshal([]byte(`{"type":"triangle","payload":{}}`), &u), ErrUnknownEnvelope)
+		s.Error(json.Unmarshal([]byte(`{"type":"square","payload":{"side":"x"}}`), &u))
+
+		_, err := json.Marshal(Union[unionShape]{&unionSquare{}})
+		s.ErrorContains(err, "*tool.unionSquare is not registered")
+
+		s.Panics(func() { RegisterUnion[unionShape, unionSquare]("other") })
+		s.Panics(func() { RegisterUnion[unionShape, unionCircle]("circle2") })
+		s.Panics(func() { RegisterUnion[unionShape, *unionSquare]("square") })
+	})
+}
@@ -0,0 +1,145 @@
+package tool
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"unicode/utf8"
+)
+
+// UnmarshalJSON Implements json.Unmarshaler, accepts JSON string or null
+func (s *Varchar) UnmarshalJSON(b []byte) error {
+	var str *string
+	if err := json.Unmarshal(b, &str); err != nil {
+		return err
+	}
+	if str == nil {
+		*s = ""
+		return nil
+	}
+	*s = Varchar(*str)
+	return nil
+}
+
+// MarshalText Implements encoding.TextMarshaler
+func (s Varchar) MarshalText() ([]byte, error) {
+	return s.Bytes(), nil
+}
+
+// UnmarshalText Implements encoding.TextUnmarshaler
+func (s *Varchar) UnmarshalText(b []byte) error {
+	*s = Varchar(b)
+	return nil
+}
+
+// Value Implements driver.Valuer, stored as string
+func (s Varchar) Value() (driver.Value, error) {
+	return string(s), nil
+}
+
+// Scan Implements sql.Scanner, accepts string, []byte and NULL as empty
+func (s *Varchar) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*s = ""
+	case string:
+		*s = Varchar(v)
+	case []byte:
+		*s = Varchar(v)
+	default:
+		return fmt.Errorf("varchar: cannot scan %T", src)
+	}
+	return nil
+}
+
+// ErrVarcharTooLong Returned by VarcharBuilder writes cut at its limit
+var ErrVarcharTooLong = errors.New("varchar length limit exceeded")
+
+// VarcharBuilder Accumulates a Varchar in a pooled buffer, optionally limited in length.
+// Writes past the limit are cut at a UTF-8 boundary. Not safe for concurrent use.
+type VarcharBuilder struct {
+	buf   *bytes.Buffer
+	limit int
+}
+
+var varcharBuffers = sync.Pool{New: func() any { return new(bytes.Buffer) }}
+
+// NewVarcharBuilder Returns empty builder, optional limit caps the length in bytes, <1 for unlimited
+func NewVarcharBuilder(limit ...int) *VarcharBuilder {
+	b := &VarcharBuilder{}
+	if len(limit) > 0 {
+		b.limit = limit[0]
+	}
+	return b
+}
+
+// Write Implements io.Writer, returns ErrVarcharTooLong if p was cut at the limit
+func (b *VarcharBuilder) Write(p []byte) (int, error) {
+	if b.buf == nil {
+		b.buf = varcharBuffers.Get().(*bytes.Buffer)
+	}
+	if b.limit > 0 && b.buf.Len()+len(p) > b.limit {
+		n, _ := b.buf.Write(p[:runeBoundary(p, b.limit-b.buf.Len())])
+		return n, ErrVarcharTooLong
+	}
+	return b.buf.Write(p)
+}
+
+// WriteString Implements io.StringWriter, returns ErrVarcharTooLong if s was cut at the limit
+func (b *VarcharBuilder) WriteString(s string) (int, error) {
+	return b.Write([]byte(s))
+}
+
+// WriteJSON Appends JSON encoding of v
+func (b *VarcharBuilder) WriteJSON(v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = b.Write(data)
+	return err
+}
+
+// Truncate Cuts the content to at most max bytes at a UTF-8 boundary
+func (b *VarcharBuilder) Truncate(max int) {
+	if b.buf != nil && b.buf.Len() > max {
+		b.buf.Truncate(runeBoundary(b.buf.Bytes(), max))
+	}
+}
+
+// Len Returns length of the content in bytes
+func (b *VarcharBuilder) Len() int {
+	if b.buf == nil {
+		return 0
+	}
+	return b.buf.Len()
+}
+
+// Varchar Returns the content and resets the builder, returning its buffer to the pool
+func (b *VarcharBuilder) Varchar() Varchar {
+	if b.buf == nil {
+		return ""
+	}
+	s := Varchar(b.buf.String())
+	b.buf.Reset()
+	varcharBuffers.Put(b.buf)
+	b.buf = nil
+	return s
+}
+
+// runeBoundary Returns the largest n<=max not splitting a UTF-8 sequence of p
+func runeBoundary(p []byte, max int) int {
+	if max <= 0 {
+		return 0
+	}
+	if max >= len(p) {
+		return len(p)
+	}
+	for max > 0 && !utf8.RuneStart(p[max]) {
+		max--
+	}
+	return max
+}
@@ -0,0 +1,99 @@
+package tool
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+)
+
+var (
+	_ json.Marshaler           = Varchar("")
+	_ json.Unmarshaler         = (*Varchar)(nil)
+	_ encoding.TextMarshaler   = Varchar("")
+	_ encoding.TextUnmarshaler = (*Varchar)(nil)
+	_ driver.Valuer            = Varchar("")
+	_ sql.Scanner              = (*Varchar)(nil)
+)
+
+func (s *ToolTestSuite) TestVarchar() {
+	s.Run("json", func() {
+		type model struct {
+			Name  Varchar            `json:"name"`
+			Empty Varchar            `json:"empty"`
+			Keys  map[Varchar]string `json:"keys"`
+		}
+		in := model{Name: "a \"b\"", Keys: map[Varchar]string{"k": "v"}}
+		b, err := json.Marshal(in)
+		s.Require().NoError(err)
+		s.JSONEq(`{"name":"a \"b\"","empty":"","keys":{"k":"v"}}`, string(b))
+		b2, err := json.Marshal(&in)
+		s.Require().NoError(err)
+		s.Equal(b, b2)
+
+		var out model
+		s.Require().NoError(json.Unmarshal(b, &out))
+		s.Equal(in, out)
+
+		out.Name = "set"
+		s.NoError(json.Unmarshal([]byte(`{"name":null}`), &out))
+		s.Empty(out.Name)
+		s.Error(json.Unmarshal([]byte(`{"name":1}`), &out))
+	})
+
+	s.Run("text", func() {
+		var v Varchar
+		s.NoError(v.UnmarshalText([]byte("text")))
+		b, err := v.MarshalText()
+		s.NoError(err)
+		s.Equal("text", string(b))
+	})
+
+	s.Run("sql", func() {
+		value, err := Varchar("db").Value()
+		s.NoError(err)
+		s.Equal("db", value)
+
+		var v Varchar
+		s.NoError(v.Scan([]byte("bytes")))
+		s.Equal(Varchar("bytes"), v)
+		s.NoError(v.Scan("string"))
+		s.Equal(Varchar("string"), v)
+		s.NoError(v.Scan(nil))
+		s.Empty(v)
+		s.EqualError(v.Scan(1), "varchar: cannot scan int")
+	})
+}
+
+func (s *ToolTestSuite) TestVarcharBuilder() {
+	b := NewVarcharBuilder()
+	s.Empty(b.Varchar())
+	_, err := b.WriteString(`{"a":`)
+	s.NoError(err)
+	s.NoError(b.WriteJSON([]int{1, 2}))
+	_, err = b.Write([]byte("}"))
+	s.NoError(err)
+	s.Equal(11, b.Len())
+	s.Equal(Varchar(`{"a":[1,2]}`), b.Varchar())
+	s.Zero(b.Len())
+
+	b.WriteString("héllo")
+	b.Truncate(2)
+	s.Equal(Varchar("h"), b.Varchar())
+
+	limited := NewVarcharBuilder(5)
+	n, err := limited.WriteString("abcé")
+	s.NoError(err)
+	s.Equal(5, n)
+	n, err = limited.WriteString("d")
+	s.ErrorIs(err, ErrVarcharTooLong)
+	s.Zero(n)
+	s.Equal(Varchar("abcé"), limited.Varchar())
+
+	limited.WriteString("abcd")
+	n, err = limited.WriteString("éé")
+	s.ErrorIs(err, ErrVarcharTooLong)
+	s.Zero(n)
+	s.ErrorIs(limited.WriteJSON("x"), ErrVarcharTooLong)
+	s.Equal(Varchar(`abcd"`), limited.Varchar())
+}
@@ -0,0 +1,153 @@
+package tool
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// WatchPollInterval Maximum interval between WatchPaths scans, shorter debounce makes scans more frequent
+var WatchPollInterval = 250 * time.Millisecond
+
+const (
+	// FileCreated Path appeared
+	FileCreated FileOp = iota + 1
+	// FileModified File size or modification time changed
+	FileModified
+	// FileRemoved Path disappeared
+	FileRemoved
+)
+
+type (
+	// FileOp Kind of file change
+	FileOp uint8
+
+	// FileEvent Change of a watched path
+	FileEvent struct {
+		Path string
+		Op   FileOp
+	}
+
+	fileStamp struct {
+		modTime time.Time
+		size    int64
+	}
+)
+
+// String Implements fmt.Stringer
+func (op FileOp) String() string {
+	switch op {
+	case FileCreated:
+		return "created"
+	case FileModified:
+		return "modified"
+	case FileRemoved:
+		return "removed"
+	}
+	return "unknown"
+}
+
+// WatchPaths Watches files and directories (recursively) for changes until ctx is done, returns ctx.Err().
+// Changes are collected until paths stay unchanged for debounce duration, then passed to onChange as one batch sorted by path.
+// Paths may not exist yet, their creation is reported. Change detection is polling based, so it works on any OS and filesystem.
+func WatchPaths(ctx context.Context, paths []string, debounce time.Duration, onChange func([]FileEvent)) error {
+	interval := WatchPollInterval
+	if debounce > 0 && debounce < interval {
+		interval = debounce
+	}
+
+	prev := scanPaths(paths)
+	pending := map[string]FileOp{}
+	var lastChange time.Time
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		cur := scanPaths(paths)
+		events := diffSnapshots(prev, cur)
+		prev = cur
+		now := time.Now()
+		if len(events) > 0 {
+			lastChange = now
+			for _, event := range events {
+				mergeFileOp(pending, event)
+			}
+		}
+		if len(pending) == 0 || now.Sub(lastChange) < debounce {
+			continue
+		}
+
+		batch := make([]FileEvent, 0, len(pending))
+		for path, op := range pending {
+			batch = append(batch, FileEvent{Path: path, Op: op})
+		}
+		sort.Slice(batch, func(i, j int) bool { return batch[i].Path < batch[j].Path })
+		pending = map[string]FileOp{}
+		onChange(batch)
+	}
+}
+
+// scanPaths Returns stamps of all files and directories under the paths, only existence of directories is tracked
+func scanPaths(paths []string) map[string]fileStamp {
+	snapshot := map[string]fileStamp{}
+	for _, root := range paths {
+		_ = filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return nil // vanished or unreadable, reported as missing
+			}
+			if d.IsDir() {
+				snapshot[p] = fileStamp{size: -1}
+				return nil
+			}
+			if info, err := d.Info(); err == nil {
+				snapshot[p] = fileStamp{modTime: info.ModTime(), size: info.Size()}
+			}
+			return nil
+		})
+	}
+	return snapshot
+}
+
+func diffSnapshots(prev, cur map[string]fileStamp) []FileEvent {
+	var events []FileEvent
+	for path, stamp := range cur {
+		prevStamp, ok := prev[path]
+		switch {
+		case !ok:
+			events = append(events, FileEvent{Path: path, Op: FileCreated})
+		case prevStamp != stamp:
+			events = append(events, FileEvent{Path: path, Op: FileModified})
+		}
+	}
+	for path := range prev {
+		if _, ok := cur[path]; !ok {
+			events = append(events, FileEvent{Path: path, Op: FileRemoved})
+		}
+	}
+	return events
+}
+
+// mergeFileOp Folds a new event into the pending batch, so the batch describes the net change
+func mergeFileOp(pending map[string]FileOp, event FileEvent) {
+	prevOp, ok := pending[event.Path]
+	switch {
+	case !ok:
+		pending[event.Path] = event.Op
+	case prevOp == FileCreated && event.Op == FileRemoved:
+		delete(pending, event.Path)
+	case prevOp == FileCreated:
+		// still a creation
+	case prevOp == FileRemoved && event.Op == FileCreated:
+		pending[event.Path] = FileModified
+	default:
+		pending[event.Path] = event.Op
+	}
+}
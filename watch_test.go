@@ -0,0 +1,77 @@
+package tool
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+func (s *ToolTestSuite) TestWatchPaths() {
+	dir := s.T().TempDir()
+	existing := filepath.Join(dir, "existing.txt")
+	s.Require().NoError(os.WriteFile(existing, []byte("a"), 0o644))
+
+	var (
+		mu      sync.Mutex
+		batches [][]FileEvent
+	)
+	received := func() [][]FileEvent {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([][]FileEvent(nil), batches...)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- WatchPaths(ctx, []string{dir}, 20*time.Millisecond, func(events []FileEvent) {
+			mu.Lock()
+			batches = append(batches, events)
+			mu.Unlock()
+		})
+	}()
+	time.Sleep(30 * time.Millisecond)
+
+	s.Require().NoError(os.MkdirAll(filepath.Join(dir, "sub"), 0o755))
+	s.Require().NoError(os.WriteFile(filepath.Join(dir, "sub", "new.txt"), []byte("b"), 0o644))
+	s.Require().NoError(os.WriteFile(existing, []byte("changed"), 0o644))
+	temp := filepath.Join(dir, "temp.txt")
+	s.Require().NoError(os.WriteFile(temp, nil, 0o644))
+
+	s.Eventually(func() bool { return len(received()) > 0 }, time.Second, 5*time.Millisecond)
+	s.Require().NoError(os.Remove(temp))
+	s.Eventually(func() bool {
+		for _, batch := range received() {
+			for _, event := range batch {
+				if event.Path == temp && event.Op == FileRemoved {
+					return true
+				}
+			}
+		}
+		return false
+	}, time.Second, 5*time.Millisecond)
+
+	cancel()
+	s.ErrorIs(<-done, context.Canceled)
+
+	first := received()[0]
+	s.Contains(first, FileEvent{Path: filepath.Join(dir, "sub"), Op: FileCreated})
+	s.Contains(first, FileEvent{Path: filepath.Join(dir, "sub", "new.txt"), Op: FileCreated})
+	s.Contains(first, FileEvent{Path: existing, Op: FileModified})
+}
+
+func (s *ToolTestSuite) TestMergeFileOp() {
+	pending := map[string]FileOp{}
+	mergeFileOp(pending, FileEvent{Path: "a", Op: FileCreated})
+	mergeFileOp(pending, FileEvent{Path: "a", Op: FileModified})
+	s.Equal(FileCreated, pending["a"])
+	mergeFileOp(pending, FileEvent{Path: "a", Op: FileRemoved})
+	s.NotContains(pending, "a")
+
+	mergeFileOp(pending, FileEvent{Path: "b", Op: FileRemoved})
+	mergeFileOp(pending, FileEvent{Path: "b", Op: FileCreated})
+	s.Equal(FileModified, pending["b"])
+	s.Equal("modified", pending["b"].String())
+}
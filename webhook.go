@@ -0,0 +1,105 @@
+package tool
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Scheme Webhook signature header format
+type Scheme int
+
+const (
+	// SchemeHexSHA256 Header is a plain hex HMAC-SHA256 of the payload
+	SchemeHexSHA256 Scheme = iota
+	// SchemeBase64SHA256 Header is a base64 HMAC-SHA256 of the payload (Shopify and alike)
+	SchemeBase64SHA256
+	// SchemeGitHub Header is "sha256=<hex>", as in X-Hub-Signature-256
+	SchemeGitHub
+	// SchemeStripe Header is "t=<unix>,v1=<hex>[,v1=<hex>]", signature covers "<t>.<payload>"
+	SchemeStripe
+)
+
+var (
+	// ErrInvalidSignature Returned when the signature is malformed or does not match
+	ErrInvalidSignature = errors.New("invalid signature")
+	// ErrSignatureExpired Returned when the signature timestamp is out of WebhookTolerance
+	ErrSignatureExpired = errors.New("signature expired")
+
+	// WebhookTolerance Maximum age of timestamped signatures
+	WebhookTolerance = 5 * time.Minute
+)
+
+// VerifyHMACSignature Checks that header carries a valid HMAC signature of payload made with secret
+func VerifyHMACSignature(payload []byte, header, secret string, scheme Scheme) error {
+	header = strings.TrimSpace(header)
+	switch scheme {
+	case SchemeHexSHA256:
+		return verifyHex(hmacSHA256(secret, payload), header)
+	case SchemeBase64SHA256:
+		sig, err := base64.StdEncoding.DecodeString(header)
+		if err != nil || !SecureEqual(sig, hmacSHA256(secret, payload)) {
+			return ErrInvalidSignature
+		}
+		return nil
+	case SchemeGitHub:
+		if !strings.HasPrefix(header, "sha256=") {
+			return fmt.Errorf("%w: sha256= prefix expected", ErrInvalidSignature)
+		}
+		return verifyHex(hmacSHA256(secret, payload), strings.TrimPrefix(header, "sha256="))
+	case SchemeStripe:
+		return verifyStripe(payload, header, secret)
+	}
+	return fmt.Errorf("unknown signature scheme %d", scheme)
+}
+
+func verifyStripe(payload []byte, header, secret string) error {
+	var (
+		timestamp  string
+		signatures []string
+	)
+	for _, part := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || len(signatures) == 0 {
+		return fmt.Errorf("%w: t= and v1= expected", ErrInvalidSignature)
+	}
+
+	expected := hmacSHA256(secret, []byte(timestamp+"."+string(payload)))
+	for _, sig := range signatures {
+		if verifyHex(expected, sig) == nil {
+			if age := clockSince(time.Unix(unix, 0)); age > WebhookTolerance || age < -WebhookTolerance {
+				return ErrSignatureExpired
+			}
+			return nil
+		}
+	}
+	return ErrInvalidSignature
+}
+
+func verifyHex(expected []byte, sig string) error {
+	decoded, err := hex.DecodeString(sig)
+	if err != nil || !SecureEqual(decoded, expected) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func hmacSHA256(secret string, payload []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,47 @@
+package tool
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"strconv"
+	"time"
+)
+
+func (s *ToolTestSuite) TestVerifyHMACSignature() {
+	payload := []byte(`{"event":"push"}`)
+	secret := "s3cr3t"
+	sig := hex.EncodeToString(hmacSHA256(secret, payload))
+
+	s.Run("hex", func() {
+		s.NoError(VerifyHMACSignature(payload, sig, secret, SchemeHexSHA256))
+		s.ErrorIs(VerifyHMACSignature(payload, sig, "other", SchemeHexSHA256), ErrInvalidSignature)
+		s.ErrorIs(VerifyHMACSignature(payload, "zz", secret, SchemeHexSHA256), ErrInvalidSignature)
+	})
+
+	s.Run("base64", func() {
+		b64 := base64.StdEncoding.EncodeToString(hmacSHA256(secret, payload))
+		s.NoError(VerifyHMACSignature(payload, b64, secret, SchemeBase64SHA256))
+		s.ErrorIs(VerifyHMACSignature(payload, sig, secret, SchemeBase64SHA256), ErrInvalidSignature)
+	})
+
+	s.Run("github", func() {
+		s.NoError(VerifyHMACSignature(payload, "sha256="+sig, secret, SchemeGitHub))
+		s.ErrorIs(VerifyHMACSignature(payload, sig, secret, SchemeGitHub), ErrInvalidSignature)
+		s.ErrorIs(VerifyHMACSignature([]byte("tampered"), "sha256="+sig, secret, SchemeGitHub), ErrInvalidSignature)
+	})
+
+	s.Run("stripe", func() {
+		stripeHeader := func(at time.Time) string {
+			t := strconv.FormatInt(at.Unix(), 10)
+			return "t=" + t + ",v1=deadbeef,v1=" + hex.EncodeToString(hmacSHA256(secret, []byte(t+"."+string(payload))))
+		}
+		s.NoError(VerifyHMACSignature(payload, stripeHeader(time.Now()), secret, SchemeStripe))
+		s.ErrorIs(VerifyHMACSignature(payload, stripeHeader(time.Now().Add(-time.Hour)), secret, SchemeStripe), ErrSignatureExpired)
+		s.ErrorIs(VerifyHMACSignature(payload, stripeHeader(time.Now()), "other", SchemeStripe), ErrInvalidSignature)
+		s.ErrorIs(VerifyHMACSignature(payload, "v1="+sig, secret, SchemeStripe), ErrInvalidSignature)
+	})
+
+	s.Run("unknown", func() {
+		s.Error(VerifyHMACSignature(payload, sig, secret, Scheme(42)))
+	})
+}
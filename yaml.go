@@ -0,0 +1,19 @@
+package tool
+
+import (
+	"github.com/iamwavecut/tool/safetool"
+)
+
+// Yamlify Returns Varchar implementation of the YAML serialized value, returns empty on error
+func Yamlify(v any) Varchar {
+	out, err := safetool.Yamlify(v)
+	if Try(err, true) {
+		return ""
+	}
+	return Varchar(out)
+}
+
+// Unyamlify Unmarshalls YAML value to the target pointer value
+func Unyamlify[T ~[]byte | ~string](in T, target any) bool {
+	return !Try(safetool.Unyamlify(in, target), true)
+}
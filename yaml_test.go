@@ -0,0 +1,17 @@
+package tool
+
+func (s *ToolTestSuite) TestYamlify() {
+	type config struct {
+		Name Varchar `yaml:"name"`
+	}
+	out := Yamlify(config{Name: "a"})
+	s.Equal(Varchar("name: a\n"), out)
+
+	var cfg config
+	s.True(Unyamlify(out, &cfg))
+	s.Equal(Varchar("a"), cfg.Name)
+
+	s.False(Unyamlify("name: [", &cfg))
+	s.NotEmpty(testLog.buf)
+	s.Empty(Yamlify(make(chan int)))
+}